@@ -0,0 +1,184 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/miu200521358/bone_baker/pkg/application/usecase"
+	"github.com/miu200521358/bone_baker/pkg/domain/entity"
+	pRepository "github.com/miu200521358/bone_baker/pkg/infrastructure/repository"
+	"github.com/miu200521358/mlib_go/pkg/config/mlog"
+	"github.com/miu200521358/mlib_go/pkg/domain/vmd"
+	"github.com/miu200521358/mlib_go/pkg/infrastructure/repository"
+)
+
+// ウィンドウを持たずに設定JSONから焼き込みを行うコマンドラインツール
+//
+// mi18n は初期化しない（ユーザー設定に依存するため）。ログはメッセージIDのまま出力される。
+func main() {
+	settingsPath := flag.String("settings", "", "bake set settings JSON path")
+	setIndex := flag.Int("set", -1, "bake set index to process (-1: all)")
+	outputDir := flag.String("output-dir", "", "output directory (default: next to the original motion)")
+	flag.Parse()
+
+	if *settingsPath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// Ctrl+C で処理を中断する
+	var terminated atomic.Bool
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	go func() {
+		<-sigCh
+		terminated.Store(true)
+	}()
+
+	if err := run(*settingsPath, *setIndex, *outputDir, terminated.Load); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(settingsPath string, setIndex int, outputDir string, isTerminate func() bool) error {
+	fileRepo := pRepository.NewFileRepository()
+	loadUsecase := usecase.NewLoadUsecase(fileRepo, pRepository.NewParseCache(), pRepository.NewBoneAliasRepository())
+	physicsUsecase := usecase.NewPhysicsUsecase()
+	simulationUsecase := usecase.NewSimulationUsecase()
+	outputUsecase := usecase.NewOutputUsecase()
+	saveUsecase := usecase.NewSaveUsecase(fileRepo, pRepository.NewReportRepository())
+	validationUsecase := usecase.NewValidationUsecase()
+
+	bakeSets, physicsRecords, windRecords, err := loadUsecase.LoadFile(settingsPath)
+	if err != nil {
+		return err
+	}
+
+	runner := &bakeRunner{
+		loadUsecase:       loadUsecase,
+		physicsUsecase:    physicsUsecase,
+		simulationUsecase: simulationUsecase,
+		outputUsecase:     outputUsecase,
+		saveUsecase:       saveUsecase,
+		validationUsecase: validationUsecase,
+		physicsRecords:    physicsRecords,
+		windRecords:       windRecords,
+		outputDir:         outputDir,
+		isTerminate:       isTerminate,
+	}
+
+	for _, bakeSet := range bakeSets {
+		if setIndex >= 0 && bakeSet.Index != setIndex {
+			continue
+		}
+
+		if err := runner.bake(bakeSet); err != nil {
+			return fmt.Errorf("bake set %d: %w", bakeSet.Index, err)
+		}
+	}
+
+	return nil
+}
+
+// bakeRunner 焼き込み処理に必要なユースケースと全体設定
+type bakeRunner struct {
+	loadUsecase       *usecase.LoadUsecase
+	physicsUsecase    *usecase.PhysicsUsecase
+	simulationUsecase *usecase.SimulationUsecase
+	outputUsecase     *usecase.OutputUsecase
+	saveUsecase       *usecase.SaveUsecase
+	validationUsecase *usecase.ValidationUsecase
+	physicsRecords    []*entity.PhysicsRecord
+	windRecords       []*entity.WindRecord
+	outputDir         string
+	isTerminate       func() bool
+}
+
+// bake 1セット分の読み込み・物理演算・出力を行う
+func (r *bakeRunner) bake(bakeSet *entity.BakeSet) error {
+	start := time.Now()
+
+	if err := r.loadUsecase.LoadModel(bakeSet, bakeSet.OriginalModelPath); err != nil {
+		return err
+	}
+	r.validationUsecase.LogIssues(r.validationUsecase.Validate(bakeSet.OriginalModel, bakeSet.BoneNameMap))
+	if err := r.loadUsecase.LoadMotion(bakeSet, bakeSet.OriginalMotionPath); err != nil {
+		return err
+	}
+
+	if bakeSet.OriginalModel == nil || bakeSet.OriginalMotion == nil {
+		return fmt.Errorf("original model or motion is not set")
+	}
+	r.validationUsecase.LogCompatibility(
+		r.validationUsecase.CheckCompatibility(bakeSet.OriginalModel, bakeSet.OriginalMotion, bakeSet.BoneNameMap))
+	if len(bakeSet.OutputRecords) == 0 {
+		return fmt.Errorf("no output records")
+	}
+
+	physicsWorldMotion := vmd.NewVmdMotion("")
+	physicsModelMotion := vmd.NewVmdMotion("")
+	windMotion := vmd.NewVmdMotion("")
+
+	r.physicsUsecase.ApplyPhysicsWorldMotion(physicsWorldMotion, r.physicsRecords)
+	r.physicsUsecase.ApplyPhysicsModelMotion(physicsWorldMotion, physicsModelMotion, bakeSet.RigidBodyRecords, bakeSet.OriginalModel)
+	r.physicsUsecase.ApplyWindMotion(windMotion, r.windRecords)
+	r.physicsUsecase.ApplyBakeSetWindMotion(physicsModelMotion, bakeSet, r.windRecords)
+
+	if err := r.simulationUsecase.Simulate(
+		bakeSet, physicsWorldMotion, physicsModelMotion, windMotion, func() {}, r.isTerminate,
+	); err != nil {
+		return err
+	}
+
+	bakeSet.OutputMotionPath = bakeSet.CreateOutputMotionPath()
+	if r.outputDir != "" {
+		bakeSet.OutputMotionPath = filepath.Join(r.outputDir, filepath.Base(bakeSet.OutputMotionPath))
+	}
+
+	outputBoneFlags, isContainsReduce := r.outputUsecase.GetBakedBoneFlags(
+		bakeSet.OriginalModel,
+		bakeSet.OriginalMotion,
+		bakeSet.OutputRecords,
+	)
+
+	motions, report, err := r.outputUsecase.ProcessOutputMotions(
+		bakeSet.OriginalModel,
+		bakeSet.OriginalMotion,
+		bakeSet.OutputMotion,
+		bakeSet.OutputMotionPath,
+		bakeSet.OutputRecords,
+		bakeSet.OutputSplit,
+		bakeSet.BoneNameMap,
+		bakeSet.BoneAliasMap,
+		bakeSet.OutputBoneNameType,
+		outputBoneFlags,
+		isContainsReduce,
+		func() {},
+		r.isTerminate,
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, motion := range motions {
+		rep := repository.NewVmdRepository(true)
+		if err := rep.Save("", motion, false); err != nil {
+			return err
+		}
+		mlog.I(fmt.Sprintf("saved: %s [%.0f-%.0f]", motion.Path(), motion.MinFrame(), motion.MaxFrame()))
+	}
+
+	if err := r.saveUsecase.SaveBakeReport(report, bakeSet.OutputMotionPath); err != nil {
+		return err
+	}
+
+	mlog.I(fmt.Sprintf("bake set %d finished: %s", bakeSet.Index, time.Since(start)))
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"math"
+	"sort"
+
+	"github.com/miu200521358/bone_baker/pkg/domain/entity"
+	"github.com/miu200521358/mlib_go/pkg/domain/mmath"
+)
+
+// 外れ値として除外する頂点の割合（両端それぞれ）
+const collisionFitTrimRatio = 0.05
+
+// 外れ値の除外を行う最小頂点数
+const collisionFitTrimMinCount = 20
+
+// collisionFit 主成分分析で当てはめた剛体の配置
+type collisionFit struct {
+	Position *mmath.MVec3 // 剛体の中心位置
+	Size     *mmath.MVec3 // 剛体の大きさ（PMXの剛体サイズ形式）
+	Axes     [3]*mmath.MVec3
+}
+
+// fitCollisionProxy ウェイト頂点の主成分軸に沿って、指定形状の剛体を当てはめる
+//   - 第1主成分をローカルY軸（カプセルの長軸）とする
+//   - 頂点が3つ未満の場合は当てはめ不可
+func fitCollisionProxy(
+	positions []*mmath.MVec3, shape entity.CollisionProxyShape, ratio float64,
+) (*collisionFit, bool) {
+	if len(positions) < 3 {
+		return nil, false
+	}
+
+	mean := [3]float64{}
+	for _, p := range positions {
+		mean[0] += p.X
+		mean[1] += p.Y
+		mean[2] += p.Z
+	}
+	for i := range mean {
+		mean[i] /= float64(len(positions))
+	}
+
+	// 共分散行列
+	cov := [3][3]float64{}
+	for _, p := range positions {
+		d := [3]float64{p.X - mean[0], p.Y - mean[1], p.Z - mean[2]}
+		for i := range 3 {
+			for j := range 3 {
+				cov[i][j] += d[i] * d[j]
+			}
+		}
+	}
+	for i := range 3 {
+		for j := range 3 {
+			cov[i][j] /= float64(len(positions))
+		}
+	}
+
+	values, vectors := symmetricEigen3(cov)
+
+	// 固有値の降順に並べて、第1主成分をY軸、第2主成分をX軸とする
+	order := []int{0, 1, 2}
+	sort.SliceStable(order, func(i, j int) bool { return values[order[i]] > values[order[j]] })
+
+	axisY := columnVec3(vectors, order[0])
+	axisX := columnVec3(vectors, order[1])
+	axisZ := crossVec3(axisX, axisY)
+	length := math.Sqrt(dotVec3(axisZ, axisZ))
+	if length < 1e-8 {
+		return nil, false
+	}
+	axisZ = axisZ.MuledScalar(1 / length)
+	axes := [3]*mmath.MVec3{axisX, axisY, axisZ}
+
+	// 各軸に投影した範囲（外れ値を除く）
+	meanPosition := &mmath.MVec3{X: mean[0], Y: mean[1], Z: mean[2]}
+	center := meanPosition.Copy()
+	half := [3]float64{}
+	for i, axis := range axes {
+		projections := make([]float64, len(positions))
+		for n, p := range positions {
+			projections[n] = dotVec3(p.Subed(meanPosition), axis)
+		}
+		low, high := trimmedRange(projections)
+		half[i] = (high - low) / 2
+		center = center.Added(axis.MuledScalar((low + high) / 2))
+	}
+
+	var size *mmath.MVec3
+	switch shape {
+	case entity.CollisionProxyShapeBox:
+		size = &mmath.MVec3{X: half[0] * ratio, Y: half[1] * ratio, Z: half[2] * ratio}
+	case entity.CollisionProxyShapeCapsule:
+		// 半径は断面（X・Z）の平均、高さは両端の半球を除いた長さ
+		radius := (half[0] + half[2]) / 2 * ratio
+		height := math.Max(0, half[1]*2*ratio-radius*2)
+		size = &mmath.MVec3{X: radius, Y: height, Z: 0}
+	default:
+		radius := (half[0] + half[1] + half[2]) / 3 * ratio
+		size = &mmath.MVec3{X: radius, Y: 0, Z: 0}
+	}
+
+	return &collisionFit{Position: center, Size: size, Axes: axes}, true
+}
+
+// Radians 当てはめた軸を剛体の回転（ラジアン）に変換
+func (f *collisionFit) Radians() *mmath.MVec3 {
+	return mmath.NewMQuaternionFromAxes(f.Axes[0], f.Axes[1], f.Axes[2]).ToRadians()
+}
+
+// trimmedRange 両端の外れ値を除いた最小値と最大値
+func trimmedRange(values []float64) (float64, float64) {
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	trim := 0
+	if len(sorted) >= collisionFitTrimMinCount {
+		trim = int(float64(len(sorted)) * collisionFitTrimRatio)
+	}
+
+	return sorted[trim], sorted[len(sorted)-1-trim]
+}
+
+// symmetricEigen3 3x3対称行列の固有値と固有ベクトル（列）をヤコビ法で求める
+func symmetricEigen3(m [3][3]float64) ([3]float64, [3][3]float64) {
+	a := m
+	v := [3][3]float64{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+
+	for range 50 {
+		// 最大の非対角成分
+		p, q := 0, 1
+		if math.Abs(a[0][2]) > math.Abs(a[p][q]) {
+			p, q = 0, 2
+		}
+		if math.Abs(a[1][2]) > math.Abs(a[p][q]) {
+			p, q = 1, 2
+		}
+		if math.Abs(a[p][q]) < 1e-12 {
+			break
+		}
+
+		theta := (a[q][q] - a[p][p]) / (2 * a[p][q])
+		t := math.Copysign(1, theta) / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+		c := 1 / math.Sqrt(t*t+1)
+		s := t * c
+
+		for k := range 3 {
+			akp, akq := a[k][p], a[k][q]
+			a[k][p] = c*akp - s*akq
+			a[k][q] = s*akp + c*akq
+		}
+		for k := range 3 {
+			apk, aqk := a[p][k], a[q][k]
+			a[p][k] = c*apk - s*aqk
+			a[q][k] = s*apk + c*aqk
+		}
+		for k := range 3 {
+			vkp, vkq := v[k][p], v[k][q]
+			v[k][p] = c*vkp - s*vkq
+			v[k][q] = s*vkp + c*vkq
+		}
+	}
+
+	return [3]float64{a[0][0], a[1][1], a[2][2]}, v
+}
+
+func columnVec3(m [3][3]float64, col int) *mmath.MVec3 {
+	return &mmath.MVec3{X: m[0][col], Y: m[1][col], Z: m[2][col]}
+}
+
+func dotVec3(a, b *mmath.MVec3) float64 {
+	return a.X*b.X + a.Y*b.Y + a.Z*b.Z
+}
+
+func crossVec3(a, b *mmath.MVec3) *mmath.MVec3 {
+	return &mmath.MVec3{
+		X: a.Y*b.Z - a.Z*b.Y,
+		Y: a.Z*b.X - a.X*b.Z,
+		Z: a.X*b.Y - a.Y*b.X,
+	}
+}
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"math"
+	"testing"
+
+	"github.com/miu200521358/bone_baker/pkg/domain/entity"
+	"github.com/miu200521358/mlib_go/pkg/domain/mmath"
+)
+
+// newCylinderPositions 中心 center・軸方向 axis・半径 radius・長さ length の円柱表面上の頂点
+func newCylinderPositions(center, axis *mmath.MVec3, radius, length float64) []*mmath.MVec3 {
+	// 軸と直交する2方向
+	u := crossVec3(axis, &mmath.MVec3{X: 0, Y: 0, Z: 1})
+	if dotVec3(u, u) < 1e-8 {
+		u = crossVec3(axis, &mmath.MVec3{X: 1, Y: 0, Z: 0})
+	}
+	u = u.MuledScalar(1 / math.Sqrt(dotVec3(u, u)))
+	w := crossVec3(axis, u)
+
+	positions := make([]*mmath.MVec3, 0)
+	for i := range 11 {
+		h := length * (float64(i)/10 - 0.5)
+		for j := range 16 {
+			angle := 2 * math.Pi * float64(j) / 16
+			positions = append(positions, center.
+				Added(axis.MuledScalar(h)).
+				Added(u.MuledScalar(radius*math.Cos(angle))).
+				Added(w.MuledScalar(radius*math.Sin(angle))))
+		}
+	}
+
+	return positions
+}
+
+func TestFitCollisionProxy(t *testing.T) {
+	center := &mmath.MVec3{X: 1, Y: 5, Z: -2}
+	axis := &mmath.MVec3{X: 0.6, Y: 0.8, Z: 0}
+
+	tests := []struct {
+		name       string
+		positions  []*mmath.MVec3
+		shape      entity.CollisionProxyShape
+		wantOk     bool
+		wantSize   *mmath.MVec3
+		wantCenter *mmath.MVec3
+	}{
+		{
+			name:       "斜めの円柱にカプセル",
+			positions:  newCylinderPositions(center, axis, 0.5, 4),
+			shape:      entity.CollisionProxyShapeCapsule,
+			wantOk:     true,
+			wantSize:   &mmath.MVec3{X: 0.5, Y: 3, Z: 0},
+			wantCenter: center,
+		},
+		{
+			name:       "斜めの円柱に箱",
+			positions:  newCylinderPositions(center, axis, 0.5, 4),
+			shape:      entity.CollisionProxyShapeBox,
+			wantOk:     true,
+			wantSize:   &mmath.MVec3{X: 0.5, Y: 2, Z: 0.5},
+			wantCenter: center,
+		},
+		{
+			name:      "頂点不足",
+			positions: []*mmath.MVec3{{X: 0, Y: 0, Z: 0}, {X: 1, Y: 0, Z: 0}},
+			shape:     entity.CollisionProxyShapeCapsule,
+			wantOk:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fit, ok := fitCollisionProxy(tt.positions, tt.shape, 1.0)
+			if ok != tt.wantOk {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOk)
+			}
+			if !ok {
+				return
+			}
+
+			// 長軸はローカルY軸
+			if d := math.Abs(dotVec3(fit.Axes[1], axis)); math.Abs(d-1) > 1e-3 {
+				t.Errorf("axis Y dot = %f, want 1", d)
+			}
+
+			// 外れ値除外分だけ小さくなるため、許容誤差を広めに取る
+			for _, c := range []struct {
+				name      string
+				got, want float64
+			}{
+				{"size.X", fit.Size.X, tt.wantSize.X},
+				{"size.Y", fit.Size.Y, tt.wantSize.Y},
+				{"size.Z", fit.Size.Z, tt.wantSize.Z},
+				{"position.X", fit.Position.X, tt.wantCenter.X},
+				{"position.Y", fit.Position.Y, tt.wantCenter.Y},
+				{"position.Z", fit.Position.Z, tt.wantCenter.Z},
+			} {
+				if math.Abs(c.got-c.want) > 0.1 {
+					t.Errorf("%s = %f, want %f", c.name, c.got, c.want)
+				}
+			}
+		})
+	}
+}
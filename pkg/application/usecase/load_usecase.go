@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/miu200521358/bone_baker/pkg/domain/entity"
@@ -14,22 +16,32 @@ import (
 	"github.com/miu200521358/mlib_go/pkg/domain/mmath"
 	"github.com/miu200521358/mlib_go/pkg/domain/pmx"
 	"github.com/miu200521358/mlib_go/pkg/domain/vmd"
-	"github.com/miu200521358/mlib_go/pkg/infrastructure/repository"
 	"golang.org/x/text/encoding/japanese"
 	"golang.org/x/text/transform"
 )
 
+// PMX・VMDのボーン名の最大バイト数（Shift-JIS）
+const boneNameByteLimit = 15
+
 type LoadUsecase struct {
-	fileRepo *pRepository.FileRepository
+	fileRepo      *pRepository.FileRepository
+	parseCache    *pRepository.ParseCache
+	boneAliasRepo *pRepository.BoneAliasRepository
 }
 
-func NewLoadUsecase(fileRepo *pRepository.FileRepository) *LoadUsecase {
+func NewLoadUsecase(
+	fileRepo *pRepository.FileRepository,
+	parseCache *pRepository.ParseCache,
+	boneAliasRepo *pRepository.BoneAliasRepository,
+) *LoadUsecase {
 	return &LoadUsecase{
-		fileRepo: fileRepo,
+		fileRepo:      fileRepo,
+		parseCache:    parseCache,
+		boneAliasRepo: boneAliasRepo,
 	}
 }
 
-func (uc *LoadUsecase) LoadFile(path string) ([]*entity.BakeSet, []*entity.PhysicsRecord, error) {
+func (uc *LoadUsecase) LoadFile(path string) ([]*entity.BakeSet, []*entity.PhysicsRecord, []*entity.WindRecord, error) {
 	return uc.fileRepo.Load(path)
 }
 
@@ -39,46 +51,35 @@ func (uc *LoadUsecase) LoadMotion(bakeSet *entity.BakeSet, path string) error {
 		return nil
 	}
 
-	var wg sync.WaitGroup
-	var originalMotion, outputMotion *vmd.VmdMotion
-	errChan := make(chan error, 2)
-
-	// 元モーション読み込み
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		rep := repository.NewVmdRepository(true)
-		if motion, err := rep.Load(path); err == nil {
-			originalMotion = motion.(*vmd.VmdMotion)
-		} else {
-			errChan <- err
-		}
-	}()
-
-	// 出力モーション読み込み
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		rep := repository.NewVmdRepository(true)
-		if motion, err := rep.Load(path); err == nil {
-			outputMotion = motion.(*vmd.VmdMotion)
-		} else {
-			errChan <- err
-		}
-	}()
-
-	wg.Wait()
-	close(errChan)
+	// 一度だけ解析して、出力モーションは複製から作る
+	originalMotion, err := uc.parseCache.LoadMotion(path)
+	if err != nil {
+		return err
+	}
 
-	for err := range errChan {
+	// 分けて配布されたモーション（表情・指・分割ファイルなど）を順に重ねる
+	for _, mergePath := range bakeSet.MergeMotionPaths {
+		mergeMotion, err := uc.parseCache.LoadMotion(mergePath)
 		if err != nil {
 			return err
 		}
+		mergeMotions(originalMotion, mergeMotion, bakeSet.MotionMergeType)
+
+		mlog.I(mi18n.T("モーション重ね合わせ", map[string]any{"Path": mergePath}))
+	}
+
+	outputMotion, err := originalMotion.Copy()
+	if err != nil {
+		return err
 	}
 
 	bakeSet.OriginalMotion = originalMotion
 	bakeSet.OriginalMotionPath = path
 	bakeSet.OutputMotion = outputMotion
+	bakeSet.BoneAliasMap = make(map[string]string)
+
+	// モデルのボーン名に合わせてキーフレームのボーン名を読み替える
+	uc.ApplyBoneAliases(bakeSet)
 
 	return nil
 }
@@ -89,64 +90,61 @@ func (uc *LoadUsecase) LoadModel(bakeSet *entity.BakeSet, path string) error {
 		return nil
 	}
 
+	// 一度だけ解析して、焼き込み用モデルは加工前の複製から作る
+	originalModel, err := uc.parseCache.LoadModel(path)
+	if err != nil {
+		return err
+	}
+
+	bakeModel, err := originalModel.Copy()
+	if err != nil {
+		return err
+	}
+
 	var wg sync.WaitGroup
-	var originalModel, bakeModel *pmx.PmxModel
+	var boneNameMap map[string]string
 	errChan := make(chan error, 2)
 
-	// 元モデル読み込み
+	// 元モデル加工
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		rep := repository.NewPmxRepository(true)
-		if model, err := rep.Load(path); err == nil {
-			originalModel = model.(*pmx.PmxModel)
-
-			if err := originalModel.Bones.InsertShortageOverrideBones(); err != nil {
-				mlog.ET(mi18n.T("システム用ボーン追加失敗"), err, "")
-				errChan <- err
-				return
-			}
-
-			if err := originalModel.Bones.InsertSystemTailBones(); err != nil {
-				mlog.ET(mi18n.T("システム用ボーン追加失敗"), err, "")
-				errChan <- err
-				return
-			}
-
-			// 剛体を追加
-			uc.appendTailRigidBody(originalModel)
+		if err := originalModel.Bones.InsertShortageOverrideBones(); err != nil {
+			mlog.ET(mi18n.T("システム用ボーン追加失敗"), err, "")
+			errChan <- err
+			return
+		}
 
-			// 物理剛体の名前を変更して表示枠に追加
-			uc.insertPhysicsBonePrefix(originalModel)
-			uc.appendPhysicsBoneToDisplaySlots(originalModel)
-		} else {
+		if err := originalModel.Bones.InsertSystemTailBones(); err != nil {
+			mlog.ET(mi18n.T("システム用ボーン追加失敗"), err, "")
 			errChan <- err
+			return
 		}
+
+		// 剛体を追加
+		uc.appendTailRigidBody(originalModel, bakeSet.CollisionProxyProfile)
+
+		// 物理剛体の名前を変更して表示枠に追加
+		boneNameMap = uc.insertPhysicsBonePrefix(originalModel)
+		uc.appendPhysicsBoneToDisplaySlots(originalModel)
 	}()
 
-	// 焼き込み用モデル読み込み
+	// 焼き込み用モデル加工
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		rep := repository.NewPmxRepository(true)
-		if model, err := rep.Load(path); err == nil {
-			bakeModel = model.(*pmx.PmxModel)
-
-			if err := bakeModel.Bones.InsertShortageOverrideBones(); err != nil {
-				mlog.ET(mi18n.T("システム用ボーン追加失敗"), err, "")
-				errChan <- err
-				return
-			}
-
-			// 物理剛体の名前を変更して表示枠に追加
-			uc.insertPhysicsBonePrefix(bakeModel)
-			uc.appendPhysicsBoneToDisplaySlots(bakeModel)
-
-			// 物理剛体を無効化
-			uc.fixPhysicsRigidBodies(bakeModel)
-		} else {
+		if err := bakeModel.Bones.InsertShortageOverrideBones(); err != nil {
+			mlog.ET(mi18n.T("システム用ボーン追加失敗"), err, "")
 			errChan <- err
+			return
 		}
+
+		// 物理剛体の名前を変更して表示枠に追加
+		uc.insertPhysicsBonePrefix(bakeModel)
+		uc.appendPhysicsBoneToDisplaySlots(bakeModel)
+
+		// 物理剛体を無効化
+		uc.fixPhysicsRigidBodies(bakeModel)
 	}()
 
 	wg.Wait()
@@ -161,55 +159,321 @@ func (uc *LoadUsecase) LoadModel(bakeSet *entity.BakeSet, path string) error {
 	bakeSet.OriginalModel = originalModel
 	bakeSet.OriginalModelPath = path
 	bakeSet.BakedModel = bakeModel
+	bakeSet.BoneNameMap = boneNameMap
+
+	// 設定ファイルから読み込んだレコードをモデルに紐付け直す
+	bakeSet.RestoreRecordTrees()
+
+	// モデルのボーン名に合わせてキーフレームのボーン名を読み替える
+	uc.ApplyBoneAliases(bakeSet)
 
 	return nil
 }
 
-func (uc *LoadUsecase) appendTailRigidBody(model *pmx.PmxModel) {
-	if model == nil {
+// ApplyBoneAliases モーションのボーン名がモデルに無い場合、別名表から一致するモデルのボーン名に読み替える
+//   - 元モーション・出力モーションの両方のキーフレームを改名し、適用した対応を BoneAliasMap に記録する
+//   - モデル・モーションのどちらかが未読み込みの場合は何もしない
+func (uc *LoadUsecase) ApplyBoneAliases(bakeSet *entity.BakeSet) {
+	if bakeSet.OriginalModel == nil || bakeSet.OriginalMotion == nil {
 		return
 	}
-	vertexMap := model.Vertices.GetMapByBoneIndex(0.0)
 
-	for _, boneName := range []pmx.StandardBoneName{pmx.KNEE, pmx.ANKLE, pmx.TOE_T, pmx.HEEL, pmx.ELBOW, pmx.WRIST, pmx.EYE} {
-		for _, direction := range []pmx.BoneDirection{pmx.BONE_DIRECTION_LEFT, pmx.BONE_DIRECTION_RIGHT} {
-			bone, err := model.Bones.GetByName(boneName.StringFromDirection(direction))
-			if err != nil {
+	table := entity.NewBoneAliasTable()
+	for _, path := range bakeSet.BoneAliasPaths {
+		userTable, err := uc.boneAliasRepo.Load(path)
+		if err != nil {
+			// 読み込めないファイルは飛ばして、標準の別名表で続ける
+			continue
+		}
+		table.Merge(userTable)
+	}
+
+	// 物理ボーンはモーション側では元の名前で登録されているため、元の名前で照合する
+	originalBoneNames := bakeSet.BoneNameMap
+
+	modelBoneNames := make([]string, 0, bakeSet.OriginalModel.Bones.Length())
+	bakeSet.OriginalModel.Bones.ForEach(func(boneIndex int, bone *pmx.Bone) bool {
+		if originalBoneName, ok := originalBoneNames[bone.Name()]; ok {
+			modelBoneNames = append(modelBoneNames, originalBoneName)
+		} else {
+			modelBoneNames = append(modelBoneNames, bone.Name())
+		}
+		return true
+	})
+
+	aliases := resolveBoneAliases(bakeSet.OriginalMotion.BoneFrames.Names(), modelBoneNames, table)
+	for motionBoneName, modelBoneName := range aliases {
+		renameBoneFrames(bakeSet.OriginalMotion, motionBoneName, modelBoneName)
+		if bakeSet.OutputMotion != nil {
+			renameBoneFrames(bakeSet.OutputMotion, motionBoneName, modelBoneName)
+		}
+		bakeSet.BoneAliasMap[motionBoneName] = modelBoneName
+
+		mlog.I(mi18n.T("ボーン別名適用", map[string]any{"MotionBoneName": motionBoneName, "ModelBoneName": modelBoneName}))
+	}
+}
+
+// resolveBoneAliases モデルに無いモーションのボーン名を、別名表・末尾の「D」の有無からモデルのボーン名に対応付ける
+//   - 全角・半角、大文字・小文字、空白の違いは無視する
+//   - 既にモーションにキーフレームがあるモデルのボーンには対応付けない（キーフレームの上書きを防ぐため）
+func resolveBoneAliases(motionBoneNames, modelBoneNames []string, table *entity.BoneAliasTable) map[string]string {
+	aliases := make(map[string]string)
+
+	modelNameSet := make(map[string]bool, len(modelBoneNames))
+	modelNames := make(map[string]string, len(modelBoneNames)) // 正規化した名前 → モデルのボーン名
+	for _, name := range modelBoneNames {
+		modelNameSet[name] = true
+		if _, ok := modelNames[normalizeName(name)]; !ok {
+			modelNames[normalizeName(name)] = name
+		}
+	}
+
+	groups := make(map[string][]string) // 正規化した名前 → 同じボーンを指す名前
+	for _, names := range table.Groups {
+		for _, name := range names {
+			groups[normalizeName(name)] = append(groups[normalizeName(name)], names...)
+		}
+	}
+
+	// キーフレームがある（または対応付け済みの）モデルのボーン名
+	keyedNames := make(map[string]bool, len(motionBoneNames))
+	for _, name := range motionBoneNames {
+		keyedNames[name] = true
+	}
+
+	sortedNames := make([]string, len(motionBoneNames))
+	copy(sortedNames, motionBoneNames)
+	sort.Strings(sortedNames)
+
+	for _, motionBoneName := range sortedNames {
+		if modelNameSet[motionBoneName] {
+			continue
+		}
+
+		candidates := []string{motionBoneName}
+		candidates = append(candidates, groups[normalizeName(motionBoneName)]...)
+		if trimmed, ok := strings.CutSuffix(motionBoneName, "D"); ok {
+			candidates = append(candidates, trimmed)
+		} else {
+			candidates = append(candidates, motionBoneName+"D")
+		}
+
+		for _, candidate := range candidates {
+			modelBoneName, ok := modelNames[normalizeName(candidate)]
+			if !ok || keyedNames[modelBoneName] {
 				continue
 			}
 
-			rigidBody := pmx.NewRigidBody()
-			rigidBody.SetName(fmt.Sprintf("BBJ_%s", bone.Name()))
-			rigidBody.BoneIndex = bone.Index()
-			rigidBody.ShapeType = pmx.SHAPE_SPHERE
-			rigidBody.Position = bone.Position.Copy()
-			rigidBody.Bone = bone
-			rigidBody.IsSystem = true
-			rigidBody.CollisionGroup = byte(15) // 床剛体と同レベルで接触判定させる
-			rigidBody.CollisionGroupMask = pmx.NewCollisionGroupFromSlice([]uint16{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1})
-			rigidBody.CollisionGroupMaskValue = rigidBody.CollisionGroupMask.Value()
-
-			if _, ok := vertexMap[bone.Index()]; ok {
-				// ウェイトが乗っているボーンの場合、サイズを合わせる
-				vectorPositions := make([]*mmath.MVec3, 0)
-				for _, v := range vertexMap[bone.Index()] {
-					vectorPositions = append(vectorPositions, v.Position)
-				}
-				minVertexPosition := mmath.MinVec3(vectorPositions)
-				medianVertexPosition := mmath.MedianVec3(vectorPositions)
-				rigidBody.Size = medianVertexPosition.Subed(minVertexPosition).MuledScalar(0.3)
-			} else {
-				// ウェイトが乗っていないボーンの場合、デフォルト値を設定
-				rigidBody.Size = &mmath.MVec3{X: 0.2, Y: 0.2, Z: 0.2}
+			aliases[motionBoneName] = modelBoneName
+			keyedNames[modelBoneName] = true
+			break
+		}
+	}
+
+	return aliases
+}
+
+// mergeMotions motion に mergeMotion のボーン・モーフ・表示/IKのキーフレームを重ねる
+//   - 後勝ちの場合、mergeMotion にあるボーン・モーフは motion 側のキーフレームを全て破棄してから重ねる
+//   - 表示/IKは重ね方によらず、同じフレームのみ mergeMotion を優先する
+func mergeMotions(motion, mergeMotion *vmd.VmdMotion, mergeType entity.MotionMergeType) {
+	for _, boneName := range mergeMotion.BoneFrames.Names() {
+		if mergeType == entity.MotionMergeTypeOverride {
+			motion.BoneFrames.Delete(boneName)
+		}
+		mergeMotion.BoneFrames.Get(boneName).ForEach(func(frame float32, bf *vmd.BoneFrame) bool {
+			motion.AppendBoneFrame(boneName, bf)
+			return true
+		})
+	}
+
+	for _, morphName := range mergeMotion.MorphFrames.Names() {
+		if mergeType == entity.MotionMergeTypeOverride {
+			motion.MorphFrames.Delete(morphName)
+		}
+		mergeMotion.MorphFrames.Get(morphName).ForEach(func(frame float32, mf *vmd.MorphFrame) bool {
+			motion.AppendMorphFrame(morphName, mf)
+			return true
+		})
+	}
+
+	mergeIkFrames(motion, mergeMotion, mergeType)
+}
+
+// mergeIkFrames 表示/IKのキーフレームをIKボーン毎に重ねる
+//   - 表示/IKのキーフレームは全IKボーンの状態を1つに持つため、フレーム毎に両モーションの状態を合わせて作り直す
+//   - 置き換えの場合、重ねるモーションにあるIKボーン（表示は重ねるモーションにキーフレームがあれば表示も）は、重ねるモーションの状態のみを使う
+//   - 同じフレームは重ねるモーションを優先する
+func mergeIkFrames(motion, mergeMotion *vmd.VmdMotion, mergeType entity.MotionMergeType) {
+	baseFrames := make(map[float32]*vmd.IkFrame)
+	motion.IkFrames.ForEach(func(frame float32, ikf *vmd.IkFrame) bool {
+		baseFrames[frame] = ikf
+		return true
+	})
+	mergeFrames := make(map[float32]*vmd.IkFrame)
+	mergeBoneNames := make(map[string]bool)
+	mergeMotion.IkFrames.ForEach(func(frame float32, ikf *vmd.IkFrame) bool {
+		mergeFrames[frame] = ikf
+		for _, ik := range ikf.IkList {
+			mergeBoneNames[ik.BoneName] = true
+		}
+		return true
+	})
+	if len(mergeFrames) == 0 {
+		return
+	}
+
+	frames := make([]float32, 0, len(baseFrames)+len(mergeFrames))
+	for frame := range baseFrames {
+		frames = append(frames, frame)
+	}
+	for frame := range mergeFrames {
+		if _, ok := baseFrames[frame]; !ok {
+			frames = append(frames, frame)
+		}
+	}
+	sort.Slice(frames, func(i, j int) bool { return frames[i] < frames[j] })
+
+	isOverride := mergeType == entity.MotionMergeTypeOverride
+
+	// フレーム順に状態を引き継ぎながら、各フレームの全IKボーンの状態を作る
+	visible := true
+	boneNames := make([]string, 0) // 初出順のIKボーン名
+	enabled := make(map[string]bool)
+	applyIkFrame := func(ikf *vmd.IkFrame, isMerge bool) {
+		if !isOverride || isMerge {
+			visible = ikf.Visible
+		}
+		for _, ik := range ikf.IkList {
+			if isOverride && mergeBoneNames[ik.BoneName] != isMerge {
+				continue
+			}
+			if _, ok := enabled[ik.BoneName]; !ok {
+				boneNames = append(boneNames, ik.BoneName)
 			}
+			enabled[ik.BoneName] = ik.Enabled
+		}
+	}
+
+	// 最初のキーフレームより前は、最初のキーフレームの状態とする
+	for _, isMerge := range []bool{false, true} {
+		sourceFrames := baseFrames
+		if isMerge {
+			sourceFrames = mergeFrames
+		}
+		for _, frame := range frames {
+			if ikf, ok := sourceFrames[frame]; ok {
+				applyIkFrame(ikf, isMerge)
+				break
+			}
+		}
+	}
+
+	for _, frame := range frames {
+		if ikf, ok := baseFrames[frame]; ok {
+			applyIkFrame(ikf, false)
+		}
+		if ikf, ok := mergeFrames[frame]; ok {
+			applyIkFrame(ikf, true)
+		}
+
+		merged := vmd.NewIkFrame(frame)
+		merged.Visible = visible
+		for _, boneName := range boneNames {
+			ik := vmd.NewIkEnableFrame(frame)
+			ik.BoneName = boneName
+			ik.Enabled = enabled[boneName]
+			merged.IkList = append(merged.IkList, ik)
+		}
+		motion.AppendIkFrame(merged)
+	}
+}
+
+// renameBoneFrames モーションのボーンのキーフレームを別のボーン名に付け替える
+func renameBoneFrames(motion *vmd.VmdMotion, fromBoneName, toBoneName string) {
+	if !motion.BoneFrames.Contains(fromBoneName) {
+		return
+	}
 
-			model.RigidBodies.Append(rigidBody)
+	motion.BoneFrames.Get(fromBoneName).ForEach(func(frame float32, bf *vmd.BoneFrame) bool {
+		motion.AppendBoneFrame(toBoneName, bf)
+		return true
+	})
+	motion.BoneFrames.Delete(fromBoneName)
+}
+
+// appendTailRigidBody 衝突用剛体の設定に従って、物理ボーンとの接触判定用の剛体を追加
+func (uc *LoadUsecase) appendTailRigidBody(model *pmx.PmxModel, profile *entity.CollisionProxyProfile) {
+	if model == nil {
+		return
+	}
+	if profile == nil {
+		profile = entity.NewCollisionProxyProfile()
+	}
+	vertexMap := model.Vertices.GetMapByBoneIndex(0.0)
+
+	for _, item := range profile.Items {
+		bone, err := model.Bones.GetByName(item.BoneName)
+		if err != nil {
+			continue
+		}
+
+		rigidBody := pmx.NewRigidBody()
+		rigidBody.SetName(fmt.Sprintf("%s%s", entity.CollisionProxyPrefix, bone.Name()))
+		rigidBody.BoneIndex = bone.Index()
+		rigidBody.Position = bone.Position.Copy()
+		rigidBody.Bone = bone
+		rigidBody.IsSystem = true
+		rigidBody.CollisionGroup = byte(item.CollisionGroup)
+		rigidBody.CollisionGroupMask = pmx.NewCollisionGroupFromSlice(item.CollisionGroupMask)
+		rigidBody.CollisionGroupMaskValue = rigidBody.CollisionGroupMask.Value()
+
+		switch item.Shape {
+		case entity.CollisionProxyShapeBox:
+			rigidBody.ShapeType = pmx.SHAPE_BOX
+		case entity.CollisionProxyShapeCapsule:
+			rigidBody.ShapeType = pmx.SHAPE_CAPSULE
+		default:
+			rigidBody.ShapeType = pmx.SHAPE_SPHERE
+		}
+
+		vectorPositions := make([]*mmath.MVec3, 0)
+		for _, v := range vertexMap[bone.Index()] {
+			vectorPositions = append(vectorPositions, v.Position)
+		}
+
+		var fit *collisionFit
+		fitted := false
+		if item.SizeType == entity.CollisionProxySizeTypeFit {
+			fit, fitted = fitCollisionProxy(vectorPositions, item.Shape, item.SizeRatio)
+		}
+
+		if fitted {
+			// 主成分軸に沿って、位置・向き・大きさを合わせる
+			rigidBody.Position = fit.Position
+			rigidBody.Rotation = fit.Radians()
+			rigidBody.Size = fit.Size
+		} else if len(vectorPositions) > 0 && item.SizeType == entity.CollisionProxySizeTypeVertex {
+			// ウェイトが乗っているボーンの場合、サイズを合わせる
+			minVertexPosition := mmath.MinVec3(vectorPositions)
+			medianVertexPosition := mmath.MedianVec3(vectorPositions)
+			rigidBody.Size = medianVertexPosition.Subed(minVertexPosition).MuledScalar(item.SizeRatio)
+		} else if item.Size != nil {
+			// 固定サイズ、またはウェイトが乗っていないボーンの場合、設定値を使用
+			rigidBody.Size = item.Size.Copy()
+		} else {
+			rigidBody.Size = &mmath.MVec3{X: 0.2, Y: 0.2, Z: 0.2}
 		}
+
+		model.RigidBodies.Append(rigidBody)
 	}
 
 	model.RigidBodies.Setup(model.Bones)
 }
 
+// 表示枠に無い物理ボーンを追加するための表示枠名
+const physicsDisplaySlotName = "Physics"
+
 // appendPhysicsBoneToDisplaySlots 物理ボーンを表示枠に追加
 func (uc *LoadUsecase) appendPhysicsBoneToDisplaySlots(model *pmx.PmxModel) {
 	if model == nil {
@@ -236,7 +500,7 @@ func (uc *LoadUsecase) appendPhysicsBoneToDisplaySlots(model *pmx.PmxModel) {
 			if physicsDisplaySlot == nil {
 				// 物理ボーン用の表示枠がまだない場合、作成する
 				physicsDisplaySlot = pmx.NewDisplaySlot()
-				physicsDisplaySlot.SetName("Physics")
+				physicsDisplaySlot.SetName(physicsDisplaySlotName)
 			}
 
 			// 物理ボーンを表示枠に追加
@@ -261,26 +525,57 @@ func (uc *LoadUsecase) appendPhysicsBoneToDisplaySlots(model *pmx.PmxModel) {
 }
 
 // insertPhysicsBonePrefix 物理ボーンの名前に接頭辞を追加
-func (uc *LoadUsecase) insertPhysicsBonePrefix(model *pmx.PmxModel) {
+// 改名後の名前から元の名前への対応表を返す（元の名前が重複するボーンも、改名後の名前は重複しない）
+func (uc *LoadUsecase) insertPhysicsBonePrefix(model *pmx.PmxModel) map[string]string {
+	boneNameMap := make(map[string]string)
 	if model == nil {
-		return
+		return boneNameMap
 	}
 
 	digits := int(math.Log10(float64(model.Bones.Length()))) + 1
 
+	// 改名しないボーンの名前は使用済みとする
+	usedNames := make(map[string]struct{})
+	model.Bones.ForEach(func(boneIndex int, bone *pmx.Bone) bool {
+		if !bone.HasDynamicPhysics() {
+			usedNames[bone.Name()] = struct{}{}
+		}
+		return true
+	})
+
 	// 物理ボーンの名前に接頭辞を追加
 	model.Bones.ForEach(func(boneIndex int, bone *pmx.Bone) bool {
 		if bone.HasDynamicPhysics() {
-			// ボーンINDEXを0埋めして設定
-			formattedBoneName := fmt.Sprintf("BB%0*d_%s", digits, boneIndex, bone.Name())
-
-			// BoneNameEncodingServiceを使用
-			bone.SetName(uc.encodeName(formattedBoneName, 15))
+			bakeBoneName := uc.uniqueBakeBoneName(bone.Name(), boneIndex, digits, usedNames)
+			usedNames[bakeBoneName] = struct{}{}
+			boneNameMap[bakeBoneName] = bone.Name()
+			bone.SetName(bakeBoneName)
 		}
 		return true
 	})
 
 	model.Bones.UpdateNameIndexes()
+
+	return boneNameMap
+}
+
+// uniqueBakeBoneName 使用済みの名前と重複しない、焼き込み用のボーン名を生成
+//   - ボーンINDEXを0埋めした接頭辞を付けて、バイト制限で切り詰める
+//   - 切り詰めで重複した場合は、接頭辞に連番を加える（接頭辞自体は制限内に収まるため、必ず重複しない名前になる）
+func (uc *LoadUsecase) uniqueBakeBoneName(
+	boneName string, boneIndex, digits int, usedNames map[string]struct{},
+) string {
+	for n := 0; ; n++ {
+		prefix := fmt.Sprintf("BB%0*d_", digits, boneIndex)
+		if n > 0 {
+			prefix = fmt.Sprintf("BB%0*d_%d_", digits, boneIndex, n)
+		}
+
+		bakeBoneName := uc.encodeName(prefix+boneName, boneNameByteLimit)
+		if _, ok := usedNames[bakeBoneName]; !ok {
+			return bakeBoneName
+		}
+	}
 }
 
 // fixPhysicsRigidBodies 物理剛体を修正
@@ -299,6 +594,9 @@ func (uc *LoadUsecase) fixPhysicsRigidBodies(model *pmx.PmxModel) {
 
 // encodeName ボーン名を指定されたバイト制限でエンコード
 func (uc *LoadUsecase) encodeName(name string, limit int) string {
+	// CP932で表現できない文字は置き換える
+	name = uc.replaceUnencodableRunes(name)
+
 	// Encode to CP932
 	cp932Encoder := japanese.ShiftJIS.NewEncoder()
 	cp932Encoded, err := cp932Encoder.String(name)
@@ -354,3 +652,17 @@ func (uc *LoadUsecase) encodeName(name string, limit int) string {
 
 	return decodedText
 }
+
+// replaceUnencodableRunes CP932で表現できない文字を "_" に置き換える
+func (uc *LoadUsecase) replaceUnencodableRunes(name string) string {
+	var sb strings.Builder
+	encoder := japanese.ShiftJIS.NewEncoder()
+	for _, r := range name {
+		if _, err := encoder.String(string(r)); err != nil {
+			sb.WriteRune('_')
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
@@ -0,0 +1,261 @@
+package usecase
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/miu200521358/bone_baker/pkg/domain/entity"
+	"github.com/miu200521358/bone_baker/pkg/testutil"
+	"github.com/miu200521358/mlib_go/pkg/domain/vmd"
+)
+
+func TestLoadUsecase_encodeName(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		limit int
+		want  string
+	}{
+		{name: "制限内の名前はそのまま", input: "センター", limit: 15, want: "センター"},
+		{name: "空文字", input: "", limit: 15, want: ""},
+		{name: "ちょうど制限バイト数", input: "abc", limit: 3, want: "abc"},
+		{name: "半角は制限バイト数で切り詰め", input: "abcdef", limit: 3, want: "abc"},
+		{name: "全角は文字単位で切り詰め", input: "BB01_あいうえおかきくけこ", limit: 15, want: "BB01_あいうえお"},
+		{name: "全角の途中で切れる場合は手前まで", input: "BB001_あいうえおか", limit: 15, want: "BB001_あいうえ"},
+		{name: "機種依存文字", input: "左髪①", limit: 15, want: "左髪①"},
+		{name: "CP932外の文字は置換", input: "髪😀", limit: 15, want: "髪_"},
+	}
+
+	uc := &LoadUsecase{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := uc.encodeName(tt.input, tt.limit); got != tt.want {
+				t.Errorf("encodeName(%q, %d) = %q, want %q", tt.input, tt.limit, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadUsecase_uniqueBakeBoneName(t *testing.T) {
+	tests := []struct {
+		name      string
+		boneName  string
+		boneIndex int
+		digits    int
+		usedNames []string
+		want      string
+	}{
+		{name: "重複なし", boneName: "髪1", boneIndex: 5, digits: 2, want: "BB05_髪1"},
+		{name: "既存ボーンと重複", boneName: "髪1", boneIndex: 5, digits: 2, usedNames: []string{"BB05_髪1"}, want: "BB05_1_髪1"},
+		{name: "切り詰め後に重複", boneName: "あいうえおかきくけこ", boneIndex: 3, digits: 2, usedNames: []string{"BB03_あいうえお"}, want: "BB03_1_あいうえ"},
+		{name: "連番も重複", boneName: "髪1", boneIndex: 5, digits: 2, usedNames: []string{"BB05_髪1", "BB05_1_髪1"}, want: "BB05_2_髪1"},
+		{name: "CP932外の文字は置換", boneName: "髪😀", boneIndex: 1, digits: 1, want: "BB1_髪_"},
+	}
+
+	uc := &LoadUsecase{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usedNames := make(map[string]struct{})
+			for _, usedName := range tt.usedNames {
+				usedNames[usedName] = struct{}{}
+			}
+
+			if got := uc.uniqueBakeBoneName(tt.boneName, tt.boneIndex, tt.digits, usedNames); got != tt.want {
+				t.Errorf("uniqueBakeBoneName(%q) = %q, want %q", tt.boneName, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadUsecase_insertPhysicsBonePrefix(t *testing.T) {
+	// 物理ボーン2つを同じ名前にする
+	model := testutil.NewModel()
+	bone, _ := model.Bones.GetByName(testutil.BoneHair2)
+	bone.SetName(testutil.BoneHair1)
+	model.Bones.UpdateNameIndexes()
+
+	uc := &LoadUsecase{}
+	boneNameMap := uc.insertPhysicsBonePrefix(model)
+
+	if len(boneNameMap) != 2 {
+		t.Fatalf("boneNameMap = %v, want 2 entries", boneNameMap)
+	}
+	for bakeBoneName, originalBoneName := range boneNameMap {
+		if originalBoneName != testutil.BoneHair1 {
+			t.Errorf("boneNameMap[%s] = %s, want %s", bakeBoneName, originalBoneName, testutil.BoneHair1)
+		}
+		if _, err := model.Bones.GetByName(bakeBoneName); err != nil {
+			t.Errorf("bake bone %s not found", bakeBoneName)
+		}
+	}
+}
+
+func TestResolveBoneAliases(t *testing.T) {
+	modelBoneNames := []string{"全ての親", "センター", "グルーブ", "上半身", "左足", "左足D", "右足ＩＫ", "左ひじ", "髪"}
+
+	userTable := &entity.BoneAliasTable{}
+	userTable.Append("髪", "ヘア")
+
+	tests := []struct {
+		name            string
+		motionBoneNames []string
+		table           *entity.BoneAliasTable
+		want            map[string]string
+	}{
+		{
+			name:            "一致する名前はそのまま",
+			motionBoneNames: []string{"センター", "上半身"},
+			table:           entity.NewBoneAliasTable(),
+			want:            map[string]string{},
+		},
+		{
+			name:            "標準の別名と英語名",
+			motionBoneNames: []string{"グルーヴ", "Center", "elbow_L", "master"},
+			table:           entity.NewBoneAliasTable(),
+			want:            map[string]string{"グルーヴ": "グルーブ", "Center": "センター", "elbow_L": "左ひじ", "master": "全ての親"},
+		},
+		{
+			name:            "全角・半角の違い",
+			motionBoneNames: []string{"右足IK", "ｾﾝﾀｰ"},
+			table:           entity.NewBoneAliasTable(),
+			want:            map[string]string{"右足IK": "右足ＩＫ", "ｾﾝﾀｰ": "センター"},
+		},
+		{
+			name:            "末尾のDの有無",
+			motionBoneNames: []string{"上半身D"},
+			table:           entity.NewBoneAliasTable(),
+			want:            map[string]string{"上半身D": "上半身"},
+		},
+		{
+			name:            "キーフレームのあるボーンには読み替えない",
+			motionBoneNames: []string{"グルーブ", "グルーヴ"},
+			table:           entity.NewBoneAliasTable(),
+			want:            map[string]string{},
+		},
+		{
+			name:            "ユーザー定義の別名",
+			motionBoneNames: []string{"ヘア"},
+			table:           userTable,
+			want:            map[string]string{"ヘア": "髪"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resolveBoneAliases(tt.motionBoneNames, modelBoneNames, tt.table)
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("resolveBoneAliases() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeMotions(t *testing.T) {
+	tests := []struct {
+		name       string
+		mergeType  entity.MotionMergeType
+		wantCenter []float32
+	}{
+		{name: "後勝ち", mergeType: entity.MotionMergeTypeOverride, wantCenter: []float32{3}},
+		{name: "和集合", mergeType: entity.MotionMergeTypeUnion, wantCenter: []float32{0, 3, 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// センター 0F・10F、上半身 5F に、センター 3F・頭 7F を重ねる
+			motion := testutil.NewMotion()
+			mergeMotion := vmd.NewVmdMotion("merge.vmd")
+			mergeMotion.AppendBoneFrame(testutil.BoneCenter, vmd.NewBoneFrame(3))
+			mergeMotion.AppendBoneFrame("頭", vmd.NewBoneFrame(7))
+
+			mergeMotions(motion, mergeMotion, tt.mergeType)
+
+			for _, f := range []float32{0, 3, 10} {
+				want := slices.Contains(tt.wantCenter, f)
+				if got := motion.BoneFrames.Get(testutil.BoneCenter).Contains(f); got != want {
+					t.Errorf("center frame %v = %v, want %v", f, got, want)
+				}
+			}
+			if !motion.BoneFrames.Get(testutil.BoneUpperBody).Contains(5) {
+				t.Errorf("upper body frame 5 was removed")
+			}
+			if !motion.BoneFrames.Get("頭").Contains(7) {
+				t.Errorf("head frame 7 was not merged")
+			}
+		})
+	}
+}
+
+func TestMergeIkFrames(t *testing.T) {
+	// 元モーション: 0F 左足ＩＫ無効・右足ＩＫ有効、10F 左足ＩＫ有効・右足ＩＫ有効
+	// 重ねるモーション: 5F 右足ＩＫ無効（非表示）
+	newIkFrame := func(frame float32, visible bool, states map[string]bool) *vmd.IkFrame {
+		ikf := vmd.NewIkFrame(frame)
+		ikf.Visible = visible
+		for _, boneName := range []string{"左足ＩＫ", "右足ＩＫ"} {
+			if state, ok := states[boneName]; ok {
+				ik := vmd.NewIkEnableFrame(frame)
+				ik.BoneName = boneName
+				ik.Enabled = state
+				ikf.IkList = append(ikf.IkList, ik)
+			}
+		}
+		return ikf
+	}
+
+	tests := []struct {
+		name      string
+		mergeType entity.MotionMergeType
+		want      map[float32]map[string]bool // フレーム → IKボーン名（"表示" は表示）→ 状態
+	}{
+		{
+			name:      "後勝ち",
+			mergeType: entity.MotionMergeTypeOverride,
+			want: map[float32]map[string]bool{
+				0:  {"表示": false, "左足ＩＫ": false, "右足ＩＫ": false},
+				5:  {"表示": false, "左足ＩＫ": false, "右足ＩＫ": false},
+				10: {"表示": false, "左足ＩＫ": true, "右足ＩＫ": false},
+			},
+		},
+		{
+			name:      "和集合",
+			mergeType: entity.MotionMergeTypeUnion,
+			want: map[float32]map[string]bool{
+				0:  {"表示": true, "左足ＩＫ": false, "右足ＩＫ": true},
+				5:  {"表示": false, "左足ＩＫ": false, "右足ＩＫ": false},
+				10: {"表示": true, "左足ＩＫ": true, "右足ＩＫ": true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			motion := vmd.NewVmdMotion("")
+			motion.AppendIkFrame(newIkFrame(0, true, map[string]bool{"左足ＩＫ": false, "右足ＩＫ": true}))
+			motion.AppendIkFrame(newIkFrame(10, true, map[string]bool{"左足ＩＫ": true, "右足ＩＫ": true}))
+			mergeMotion := vmd.NewVmdMotion("merge.vmd")
+			mergeMotion.AppendIkFrame(newIkFrame(5, false, map[string]bool{"右足ＩＫ": false}))
+
+			mergeIkFrames(motion, mergeMotion, tt.mergeType)
+
+			got := make(map[float32]map[string]bool)
+			motion.IkFrames.ForEach(func(frame float32, ikf *vmd.IkFrame) bool {
+				got[frame] = map[string]bool{"表示": ikf.Visible}
+				for _, ik := range ikf.IkList {
+					got[frame][ik.BoneName] = ik.Enabled
+				}
+				return true
+			})
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("frames = %v, want %v", got, tt.want)
+			}
+			for frame, want := range tt.want {
+				if !maps.Equal(got[frame], want) {
+					t.Errorf("frame %v = %v, want %v", frame, got[frame], want)
+				}
+			}
+		})
+	}
+}
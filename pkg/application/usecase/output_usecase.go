@@ -2,13 +2,16 @@ package usecase
 
 import (
 	"fmt"
+	"math"
 	"runtime"
 	"slices"
+	"strings"
 
 	"github.com/miu200521358/bone_baker/pkg/domain/entity"
 	"github.com/miu200521358/mlib_go/pkg/config/merr"
 	"github.com/miu200521358/mlib_go/pkg/config/mi18n"
 	"github.com/miu200521358/mlib_go/pkg/config/mlog"
+	"github.com/miu200521358/mlib_go/pkg/domain/mmath"
 	"github.com/miu200521358/mlib_go/pkg/domain/pmx"
 	"github.com/miu200521358/mlib_go/pkg/domain/vmd"
 	"github.com/miu200521358/mlib_go/pkg/infrastructure/mfile"
@@ -71,50 +74,287 @@ func (uc *OutputUsecase) GetBakedBoneFlags(
 }
 
 // ProcessOutputMotion 出力モーション処理のビジネスロジック
+// 出力モーションと合わせて、出力内容の焼き込みレポートを返す
 func (uc *OutputUsecase) ProcessOutputMotions(
 	originalModel *pmx.PmxModel,
 	originalMotion *vmd.VmdMotion,
 	outputMotion *vmd.VmdMotion,
 	outputMotionPath string,
 	records []*entity.OutputRecord,
+	outputSplit *entity.OutputSplit,
+	boneNameMap map[string]string,
+	boneAliasMap map[string]string,
+	outputBoneNameType entity.OutputBoneNameType,
 	outputBoneFlags [][]entity.OutputBoneFlag,
 	isContainsReduce bool,
 	incrementCompletedCount func(),
 	isTerminate func() bool,
-) ([]*vmd.VmdMotion, error) {
+) ([]*vmd.VmdMotion, *entity.BakeReport, error) {
+	// 加算焼き込み・ブレンドの割合
+	additiveWeights := uc.additiveWeights(originalModel, records, len(outputBoneFlags[0]))
+	blendWeights := uc.blendWeights(originalModel, records, len(outputBoneFlags[0]))
+
 	// 焼き込みモーションを生成
-	bakedMotion, err := uc.bakeMotion(originalModel, originalMotion, outputMotion, outputBoneFlags, incrementCompletedCount, isTerminate)
+	bakedMotion, err := uc.bakeMotion(originalModel, originalMotion, outputMotion, boneNameMap, outputBoneFlags, additiveWeights, blendWeights, incrementCompletedCount, isTerminate)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var reducedMotion *vmd.VmdMotion
+	var reduceReports []*entity.OutputReduceReport
 
 	if isContainsReduce {
 		// 間引き後のキーフレームを生成
-		reducedBoneFrames, err := uc.generateReducedBoneFrames(originalModel, bakedMotion, incrementCompletedCount, isTerminate)
+		var reducedFrames [][]bool
+		var reducedCurves [][]*reducedBoneCurves
+		reducedFrames, reducedCurves, reduceReports, err = uc.generateReducedBoneFrames(originalModel, originalMotion, outputMotion, boneNameMap, records, outputBoneFlags, additiveWeights, blendWeights, incrementCompletedCount, isTerminate)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		// 間引きモーションを生成
-		reducedMotion, err = uc.reduceMotion(originalModel, originalMotion, outputMotion, outputBoneFlags, reducedBoneFrames, incrementCompletedCount, isTerminate)
+		reducedMotion, err = uc.reduceMotion(originalModel, originalMotion, outputMotion, boneNameMap, outputBoneFlags, additiveWeights, blendWeights, reducedFrames, reducedCurves, incrementCompletedCount, isTerminate)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	} else {
 		reducedMotion = bakedMotion
 	}
 
 	// 最大件数で分割
-	return uc.splitMotion(originalModel, originalMotion, outputMotionPath, reducedMotion, incrementCompletedCount, isTerminate)
+	outputBoneNames := uc.outputBoneNames(boneNameMap, outputBoneNameType)
+	motions, files, err := uc.splitMotion(originalModel, originalMotion, outputMotionPath, reducedMotion, records, outputSplit, outputBoneNames, incrementCompletedCount, isTerminate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	report := uc.createBakeReport(originalModel, originalMotion, outputMotionPath, boneNameMap, boneAliasMap, outputBoneFlags, files, reduceReports)
+
+	return motions, report, nil
+}
+
+// createBakeReport 出力フラグ・分割結果・間引き結果から焼き込みレポートを生成
+func (uc *OutputUsecase) createBakeReport(
+	originalModel *pmx.PmxModel,
+	originalMotion *vmd.VmdMotion,
+	outputMotionPath string,
+	boneNameMap map[string]string,
+	boneAliasMap map[string]string,
+	outputBoneFlags [][]entity.OutputBoneFlag,
+	files []*entity.BakeReportFile,
+	reduceReports []*entity.OutputReduceReport,
+) *entity.BakeReport {
+	report := &entity.BakeReport{
+		ModelPath:          originalModel.Path(),
+		OriginalMotionPath: originalMotion.Path(),
+		OutputMotionPath:   outputMotionPath,
+		Files:              files,
+		Bones:              make([]*entity.BakeReportBone, 0),
+		Reduces:            reduceReports,
+		BoneNameMap:        boneNameMap,
+		BoneAliasMap:       boneAliasMap,
+	}
+
+	for boneIndex, boneName := range originalModel.Bones.Names() {
+		bone := &entity.BakeReportBone{BoneName: boneName, OriginalBoneName: boneNameMap[boneName]}
+
+		for _, outputFlag := range outputBoneFlags[boneIndex] {
+			switch outputFlag {
+			case entity.OutputBoneFlagOriginal:
+				bone.OriginalFrameCount++
+			case entity.OutputBoneFlagBake:
+				bone.BakeFrameCount++
+			case entity.OutputBoneFlagReduce:
+				bone.ReduceFrameCount++
+			}
+		}
+
+		for _, file := range files {
+			bone.KeyCount += file.BoneKeyCounts[boneName]
+		}
+
+		if bone.KeyCount == 0 && bone.OriginalFrameCount == 0 && bone.BakeFrameCount == 0 && bone.ReduceFrameCount == 0 {
+			// 出力に関わらないボーンは載せない
+			continue
+		}
+
+		report.Bones = append(report.Bones, bone)
+	}
+
+	return report
+}
+
+// additiveWeights ボーン毎・フレーム毎の加算焼き込みの割合（加算しないフレームは負数）
+//   - 加算焼き込みの出力設定が無いボーンは nil
+func (uc *OutputUsecase) additiveWeights(
+	originalModel *pmx.PmxModel, records []*entity.OutputRecord, frameCount int,
+) [][]float64 {
+	return uc.recordFrameWeights(originalModel, records, frameCount, -1,
+		func(record *entity.OutputRecord) bool {
+			return record.Additive
+		},
+		func(record *entity.OutputRecord, frame float32) float64 {
+			if !record.Additive {
+				return -1
+			}
+			return record.AdditiveWeight
+		})
+}
+
+// blendWeights ボーン毎・フレーム毎の焼き込み結果を反映する割合（0～1）
+//   - ブレンドイン・ブレンドアウトの出力設定が無いボーンは nil
+func (uc *OutputUsecase) blendWeights(
+	originalModel *pmx.PmxModel, records []*entity.OutputRecord, frameCount int,
+) [][]float64 {
+	return uc.recordFrameWeights(originalModel, records, frameCount, 1,
+		func(record *entity.OutputRecord) bool {
+			return record.BlendInFrames > 0 || record.BlendOutFrames > 0
+		},
+		func(record *entity.OutputRecord, frame float32) float64 {
+			return record.BlendWeight(frame)
+		})
+}
+
+// recordFrameWeights ボーン毎・フレーム毎に、そのフレームを出力する出力設定から求めた割合
+//   - isTarget を満たす出力設定が無いボーンは nil
+//   - どの出力設定の区間にも含まれないフレームは defaultWeight
+//   - 複数の出力設定が重なる場合は、出力フラグと同じく後のレコードを優先する
+func (uc *OutputUsecase) recordFrameWeights(
+	originalModel *pmx.PmxModel,
+	records []*entity.OutputRecord,
+	frameCount int,
+	defaultWeight float64,
+	isTarget func(record *entity.OutputRecord) bool,
+	recordWeight func(record *entity.OutputRecord, frame float32) float64,
+) [][]float64 {
+	weights := make([][]float64, originalModel.Bones.Length())
+
+	// 出力設定毎の対象ボーンINDEX
+	recordBoneIndexes := make([][]int, len(records))
+	for i, record := range records {
+		for _, boneName := range record.ItemBoneNames() {
+			if bone, err := originalModel.Bones.GetByName(boneName); err == nil {
+				recordBoneIndexes[i] = append(recordBoneIndexes[i], bone.Index())
+			}
+		}
+	}
+
+	// isTarget を満たす出力設定の対象ボーンのみ割合を持つ
+	for i, record := range records {
+		if !isTarget(record) {
+			continue
+		}
+		for _, boneIndex := range recordBoneIndexes[i] {
+			if weights[boneIndex] != nil {
+				continue
+			}
+			weights[boneIndex] = make([]float64, frameCount)
+			for f := range weights[boneIndex] {
+				weights[boneIndex][f] = defaultWeight
+			}
+		}
+	}
+
+	// 後のレコードで上書きする
+	for i, record := range records {
+		startFrame := max(0, int(math.Ceil(float64(record.StartFrame))))
+		endFrame := min(frameCount-1, int(math.Floor(float64(record.EndFrame))))
+		for _, boneIndex := range recordBoneIndexes[i] {
+			if weights[boneIndex] == nil {
+				continue
+			}
+			for f := startFrame; f <= endFrame; f++ {
+				weights[boneIndex][f] = recordWeight(record, float32(f))
+			}
+		}
+	}
+
+	return weights
+}
+
+// frameWeight ボーン・フレームの割合（割合の無いボーンは defaultWeight）
+func frameWeight(weights [][]float64, boneIndex, frame int, defaultWeight float64) float64 {
+	if weights[boneIndex] == nil {
+		return defaultWeight
+	}
+	return weights[boneIndex][frame]
+}
+
+// weightedBoneFrame 加算焼き込み・ブレンドの割合を反映した位置・回転
+//   - 加算焼き込みの場合、加算の割合で元モーションに重ねたものを焼き込み結果とする
+//   - ブレンド中の場合、ブレンド元の姿勢から焼き込み結果へ補間する
+//     物理ボーンは区間外で物理演算により動くため、物理演算の姿勢（position, rotation）をブレンド元とする
+//     それ以外のボーンは、元モーションの姿勢をブレンド元とする
+func weightedBoneFrame(
+	originalBf *vmd.BoneFrame,
+	position *mmath.MVec3,
+	rotation *mmath.MQuaternion,
+	isPhysics bool,
+	additiveWeight float64,
+	blendWeight float64,
+) (*mmath.MVec3, *mmath.MQuaternion) {
+	bakedPosition := position
+	bakedRotation := rotation
+	if additiveWeight >= 0 {
+		bakedPosition, bakedRotation = layerBoneFrame(originalBf, position, rotation, isPhysics, additiveWeight)
+	}
+	if blendWeight >= 1 {
+		return bakedPosition, bakedRotation
+	}
+
+	fromPosition := originalBf.FilledPosition()
+	fromRotation := originalBf.FilledRotation()
+	if isPhysics {
+		fromPosition = position
+		fromRotation = rotation
+	}
+
+	return fromPosition.Lerp(bakedPosition, blendWeight), fromRotation.Slerp(bakedRotation, blendWeight)
+}
+
+// originalBoneFrames 焼き込み用のボーン名に対応する、元モーションのキーフレーム
+//   - 改名した物理ボーンは、元モーションでは元の名前で登録されているため、元の名前で取得する
+func originalBoneFrames(originalMotion *vmd.VmdMotion, boneNameMap map[string]string, boneName string) *vmd.BoneNameFrames {
+	if originalBoneName, ok := boneNameMap[boneName]; ok {
+		return originalMotion.BoneFrames.Get(originalBoneName)
+	}
+	return originalMotion.BoneFrames.Get(boneName)
+}
+
+// layerBoneFrame 焼き込み結果を、元モーションのキーフレームに割合 weight で加算した位置・回転
+//   - 物理ボーンは元モーションのキーフレームに関係なく動くため、初期姿勢からの変化量をそのまま重ねる
+//   - それ以外のボーンは、元モーションの姿勢からの変化量を重ねる
+func layerBoneFrame(
+	originalBf *vmd.BoneFrame,
+	position *mmath.MVec3,
+	rotation *mmath.MQuaternion,
+	isPhysics bool,
+	weight float64,
+) (*mmath.MVec3, *mmath.MQuaternion) {
+	originalPosition := originalBf.FilledPosition()
+	originalRotation := originalBf.FilledRotation()
+
+	deltaPosition := position
+	deltaRotation := rotation
+	if !isPhysics {
+		deltaPosition = position.Subed(originalPosition)
+		deltaRotation = originalRotation.Inverted().Muled(rotation)
+	}
+
+	layeredPosition := originalPosition.Added(deltaPosition.MuledScalar(weight))
+	layeredRotation := originalRotation.Muled(mmath.NewMQuaternion().Slerp(deltaRotation, weight))
+
+	return layeredPosition, layeredRotation
 }
 
 func (uc *OutputUsecase) bakeMotion(
 	originalModel *pmx.PmxModel,
 	originalMotion *vmd.VmdMotion,
 	outputMotion *vmd.VmdMotion,
+	boneNameMap map[string]string,
 	outputBoneFlags [][]entity.OutputBoneFlag,
+	additiveWeights [][]float64,
+	blendWeights [][]float64,
 	incrementCompletedCount func(),
 	isTerminate func() bool,
 ) (bakedMotion *vmd.VmdMotion, err error) {
@@ -145,6 +385,13 @@ func (uc *OutputUsecase) bakeMotion(
 					bf.Position = bakedBf.FilledPosition().Copy()     // 位置を保存
 					bf.Rotation = bakedBf.FilledUnitRotation().Copy() // (モーフ・付与親含む)トータル回転を保存
 
+					// 加算焼き込み・ブレンドの場合、元モーションのキーフレームと合成する
+					bone, _ := originalModel.Bones.Get(boneIndex)
+					bf.Position, bf.Rotation = weightedBoneFrame(
+						originalBoneFrames(originalMotion, boneNameMap, boneName).Get(float32(f)), bf.Position, bf.Rotation,
+						bone.HasDynamicPhysics(), frameWeight(additiveWeights, boneIndex, f, -1),
+						frameWeight(blendWeights, boneIndex, f, 1))
+
 					bakedMotion.InsertBoneFrame(boneName, bf)
 				}
 			}
@@ -162,15 +409,40 @@ func (uc *OutputUsecase) bakeMotion(
 	return bakedMotion, nil
 }
 
+// reducedBoneCurves 補間曲線で間引いたキーフレームの、前のキーフレームからの補間曲線
+type reducedBoneCurves struct {
+	startFrame int             // 補間曲線を求めた区間の開始フレーム
+	curves     *vmd.BoneCurves // 補間曲線
+}
+
+// generateReducedBoneFrames 出力設定毎の許容誤差に従って、ボーン毎に残すキーフレームを決める
+//   - 出力するキーフレームと同じく、加算焼き込み・ブレンドを反映した位置・回転で誤差を求める
+//   - 補間曲線で間引く場合、残すキーフレームに前のキーフレームからの補間曲線も求める（補間曲線を求めないボーンは nil）
 func (uc *OutputUsecase) generateReducedBoneFrames(
 	originalModel *pmx.PmxModel,
-	bakedMotion *vmd.VmdMotion,
+	originalMotion *vmd.VmdMotion,
+	outputMotion *vmd.VmdMotion,
+	boneNameMap map[string]string,
+	records []*entity.OutputRecord,
+	outputBoneFlags [][]entity.OutputBoneFlag,
+	additiveWeights [][]float64,
+	blendWeights [][]float64,
 	incrementCompletedCount func(),
 	isTerminate func() bool,
-) (reducedBoneFrames []*vmd.BoneNameFrames, err error) {
+) (reducedFrames [][]bool, reducedCurves [][]*reducedBoneCurves, reduceReports []*entity.OutputReduceReport, err error) {
 	blockSize, _ := miter.GetBlockSize(len(originalModel.Bones.Names()))
 
-	reducedBoneFrames = make([]*vmd.BoneNameFrames, len(originalModel.Bones.Names()))
+	reducedFrames = make([][]bool, len(originalModel.Bones.Names()))
+	reducedCurves = make([][]*reducedBoneCurves, len(originalModel.Bones.Names()))
+	reports := make([]*entity.OutputReduceReport, len(originalModel.Bones.Names()))
+
+	// 間引き対象レコード毎のボーン名一覧
+	recordBoneNames := make([][]string, len(records))
+	for i, record := range records {
+		if record.Reduce {
+			recordBoneNames[i] = record.ItemBoneNames()
+		}
+	}
 
 	// 間引き処理
 	err = miter.IterParallelByList(originalModel.Bones.Names(), blockSize, 1,
@@ -179,7 +451,40 @@ func (uc *OutputUsecase) generateReducedBoneFrames(
 				return merr.NewTerminateError("manual terminate")
 			}
 
-			reducedBoneFrames[boneIndex] = bakedMotion.BoneFrames.Get(boneName).Reduce()
+			reducedFrames[boneIndex] = make([]bool, len(outputBoneFlags[boneIndex]))
+			report := &entity.OutputReduceReport{BoneName: boneName}
+
+			bone, _ := originalModel.Bones.Get(boneIndex)
+			boneFrames := outputMotion.BoneFrames.Get(boneName)
+			originalFrames := originalBoneFrames(originalMotion, boneNameMap, boneName)
+			frameValue := func(f int) (*mmath.MVec3, *mmath.MQuaternion) {
+				bf := boneFrames.Get(float32(f))
+				return weightedBoneFrame(
+					originalFrames.Get(float32(f)), bf.FilledPosition(), bf.FilledRotation(),
+					bone.HasDynamicPhysics(), frameWeight(additiveWeights, boneIndex, f, -1),
+					frameWeight(blendWeights, boneIndex, f, 1))
+			}
+
+			for i, record := range records {
+				if !record.Reduce || !slices.Contains(recordBoneNames[i], boneName) {
+					continue
+				}
+
+				if record.ReduceType == entity.OutputReduceTypeCurve && reducedCurves[boneIndex] == nil {
+					reducedCurves[boneIndex] = make([]*reducedBoneCurves, len(outputBoneFlags[boneIndex]))
+				}
+
+				uc.reduceBoneFrames(frameValue, record, reducedFrames[boneIndex], reducedCurves[boneIndex], report)
+			}
+
+			if report.FrameCount > 0 {
+				for _, isKeep := range reducedFrames[boneIndex] {
+					if isKeep {
+						report.KeyCount++
+					}
+				}
+				reports[boneIndex] = report
+			}
 
 			incrementCompletedCount()
 
@@ -189,18 +494,280 @@ func (uc *OutputUsecase) generateReducedBoneFrames(
 			mlog.I(fmt.Sprintf(mi18n.T("--- [%03d/%03d] キーフレーム間引き処理中 [%s] ..."), iterIndex, allCount, originalModel.Bones.Names()[iterIndex]))
 		})
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
+	}
+
+	reduceReports = make([]*entity.OutputReduceReport, 0)
+	for _, report := range reports {
+		if report == nil {
+			continue
+		}
+		reduceReports = append(reduceReports, report)
+		mlog.I(fmt.Sprintf(mi18n.T("--- キーフレーム間引き結果 [%s] キーフレーム数: %d/%d, 最大位置誤差: %.4f, 最大回転誤差: %.4f"),
+			report.BoneName, report.KeyCount, report.FrameCount, report.MaxPositionError, report.MaxRotationError))
+	}
+
+	return reducedFrames, reducedCurves, reduceReports, nil
+}
+
+// reduceBoneFrames 出力設定の区間内で、補間した時の誤差が許容範囲に収まるようにキーフレームを残す
+//   - frameValue は出力する位置・回転
+//   - 補間曲線で間引く場合、残したキーフレームの補間曲線を keepCurves に設定する
+func (uc *OutputUsecase) reduceBoneFrames(
+	frameValue func(f int) (*mmath.MVec3, *mmath.MQuaternion),
+	record *entity.OutputRecord,
+	keepFrames []bool,
+	keepCurves []*reducedBoneCurves,
+	report *entity.OutputReduceReport,
+) {
+	start := max(0, int(record.StartFrame))
+	end := min(len(keepFrames)-1, int(record.EndFrame))
+	if end < start {
+		return
+	}
+
+	positions := make([]*mmath.MVec3, end-start+1)
+	rotations := make([]*mmath.MQuaternion, end-start+1)
+	for f := start; f <= end; f++ {
+		positions[f-start], rotations[f-start] = frameValue(f)
+	}
+
+	report.FrameCount += end - start + 1
+	keepFrames[start] = true
+	keepFrames[end] = true
+
+	if record.ReduceType == entity.OutputReduceTypeCurve {
+		uc.reduceCurveSegment(positions, rotations, 0, end-start, record, func(startIndex, endIndex int, fit *boneCurveFit) {
+			keepFrames[start+endIndex] = true
+			keepCurves[start+endIndex] = &reducedBoneCurves{startFrame: start + startIndex, curves: fit.boneCurves()}
+			report.MaxPositionError = max(report.MaxPositionError, fit.positionError)
+			report.MaxRotationError = max(report.MaxRotationError, fit.rotationError)
+		})
+		return
+	}
+
+	uc.reduceSegment(positions, rotations, 0, end-start, record, func(index int) {
+		keepFrames[start+index] = true
+	})
+
+	// 採用したキーフレーム間を補間した場合の誤差を記録
+	prev := 0
+	for i := 1; i <= end-start; i++ {
+		if !keepFrames[start+i] {
+			continue
+		}
+		for j := prev + 1; j < i; j++ {
+			positionError, rotationError := interpolationError(positions, rotations, prev, i, j)
+			report.MaxPositionError = max(report.MaxPositionError, positionError)
+			report.MaxRotationError = max(report.MaxRotationError, rotationError)
+		}
+		prev = i
+	}
+}
+
+// reduceSegment 区間内で最も誤差の大きいフレームを残して再帰的に分割する
+func (uc *OutputUsecase) reduceSegment(
+	positions []*mmath.MVec3,
+	rotations []*mmath.MQuaternion,
+	startIndex, endIndex int,
+	record *entity.OutputRecord,
+	keep func(index int),
+) {
+	if endIndex-startIndex < 2 {
+		return
+	}
+
+	maxErrorIndex := -1
+	maxErrorRatio := 0.0
+	for i := startIndex + 1; i < endIndex; i++ {
+		positionError, rotationError := interpolationError(positions, rotations, startIndex, endIndex, i)
+		errorRatio := max(
+			toleranceRatio(positionError, record.ReducePositionTolerance),
+			toleranceRatio(rotationError, record.ReduceRotationTolerance),
+		)
+		if errorRatio > maxErrorRatio {
+			maxErrorRatio = errorRatio
+			maxErrorIndex = i
+		}
+	}
+
+	splitIndex := -1
+	if maxErrorRatio > 1 {
+		splitIndex = maxErrorIndex
+	} else if record.ReduceMaxInterval > 0 && float64(endIndex-startIndex) > record.ReduceMaxInterval {
+		// 誤差は許容範囲内だが、キーフレーム間隔が広すぎる場合は中間で分割
+		splitIndex = (startIndex + endIndex) / 2
+	}
+
+	if splitIndex < 0 {
+		return
+	}
+
+	keep(splitIndex)
+	uc.reduceSegment(positions, rotations, startIndex, splitIndex, record, keep)
+	uc.reduceSegment(positions, rotations, splitIndex, endIndex, record, keep)
+}
+
+// reduceCurveSegment 区間に補間曲線を当てはめ、誤差が許容範囲を超える場合は最も誤差の大きいフレームで再帰的に分割する
+//   - keep には、採用した区間の開始・終了インデックスと、その区間の補間曲線を渡す
+func (uc *OutputUsecase) reduceCurveSegment(
+	positions []*mmath.MVec3,
+	rotations []*mmath.MQuaternion,
+	startIndex, endIndex int,
+	record *entity.OutputRecord,
+	keep func(startIndex, endIndex int, fit *boneCurveFit),
+) {
+	if endIndex <= startIndex {
+		return
+	}
+
+	fit := fitBoneCurves(positions, rotations, startIndex, endIndex, record)
+
+	splitIndex := -1
+	if fit.maxErrorRatio > 1 {
+		splitIndex = fit.maxErrorIndex
+	} else if record.ReduceMaxInterval > 0 && float64(endIndex-startIndex) > record.ReduceMaxInterval {
+		// 誤差は許容範囲内だが、キーフレーム間隔が広すぎる場合は中間で分割
+		splitIndex = (startIndex + endIndex) / 2
+	}
+
+	if splitIndex <= startIndex || splitIndex >= endIndex {
+		keep(startIndex, endIndex, fit)
+		return
+	}
+
+	uc.reduceCurveSegment(positions, rotations, startIndex, splitIndex, record, keep)
+	uc.reduceCurveSegment(positions, rotations, splitIndex, endIndex, record, keep)
+}
+
+// boneCurveFit 区間に当てはめた補間曲線と、その補間曲線で補間した時の誤差
+type boneCurveFit struct {
+	easings       [4]*entity.Easing // 移動X・移動Y・移動Z・回転の補間曲線
+	positionError float64           // 最大位置誤差
+	rotationError float64           // 最大回転誤差（度）
+	maxErrorIndex int               // 許容誤差に対する誤差の割合が最大のインデックス
+	maxErrorRatio float64           // 許容誤差に対する誤差の割合の最大値
+}
+
+// fitBoneCurves 区間の途中の位置・回転に、VMDの補間曲線を軸毎に当てはめる
+func fitBoneCurves(
+	positions []*mmath.MVec3,
+	rotations []*mmath.MQuaternion,
+	startIndex, endIndex int,
+	record *entity.OutputRecord,
+) *boneCurveFit {
+	count := endIndex - startIndex - 1
+	ts := make([]float64, max(0, count))
+	for i := range ts {
+		ts[i] = float64(i+1) / float64(endIndex-startIndex)
+	}
+
+	// 変化量の割合に対する補間曲線（変化しない軸は線形）
+	fitChannel := func(value func(index int) float64) *entity.Easing {
+		delta := value(endIndex) - value(startIndex)
+		if math.Abs(delta) < 1e-6 {
+			return entity.NewEasing(entity.EasingTypeLinear)
+		}
+		ps := make([]float64, len(ts))
+		for i := range ps {
+			ps[i] = (value(startIndex+i+1) - value(startIndex)) / delta
+		}
+		return entity.FitEasing(ts, ps)
+	}
+
+	fit := &boneCurveFit{maxErrorIndex: -1}
+	fit.easings[0] = fitChannel(func(index int) float64 { return positions[index].X })
+	fit.easings[1] = fitChannel(func(index int) float64 { return positions[index].Y })
+	fit.easings[2] = fitChannel(func(index int) float64 { return positions[index].Z })
+	fit.easings[3] = fitChannel(func(index int) float64 { return rotationDegrees(rotations[startIndex], rotations[index]) })
+
+	// 当てはめた補間曲線で補間した値と、実際の値との誤差
+	start, end := positions[startIndex], positions[endIndex]
+	for i, t := range ts {
+		index := startIndex + i + 1
+
+		position := &mmath.MVec3{
+			X: start.X + (end.X-start.X)*fit.easings[0].Ratio(t),
+			Y: start.Y + (end.Y-start.Y)*fit.easings[1].Ratio(t),
+			Z: start.Z + (end.Z-start.Z)*fit.easings[2].Ratio(t),
+		}
+		rotation := rotations[startIndex].Slerp(rotations[endIndex], fit.easings[3].Ratio(t))
+
+		positionError := position.Distance(positions[index])
+		rotationError := rotationDegrees(rotation, rotations[index])
+		fit.positionError = max(fit.positionError, positionError)
+		fit.rotationError = max(fit.rotationError, rotationError)
+
+		errorRatio := max(
+			toleranceRatio(positionError, record.ReducePositionTolerance),
+			toleranceRatio(rotationError, record.ReduceRotationTolerance),
+		)
+		if errorRatio > fit.maxErrorRatio {
+			fit.maxErrorRatio = errorRatio
+			fit.maxErrorIndex = index
+		}
 	}
 
-	return reducedBoneFrames, nil
+	return fit
+}
+
+// boneCurves 当てはめた補間曲線を、区間終了キーフレームの補間曲線に変換する
+func (fit *boneCurveFit) boneCurves() *vmd.BoneCurves {
+	curves := vmd.NewBoneCurves()
+	curves.TranslateX = newCurve(fit.easings[0])
+	curves.TranslateY = newCurve(fit.easings[1])
+	curves.TranslateZ = newCurve(fit.easings[2])
+	curves.Rotate = newCurve(fit.easings[3])
+	return curves
+}
+
+// newCurve 補間方法の制御点から、VMDの補間曲線を生成する
+func newCurve(easing *entity.Easing) *mmath.Curve {
+	return mmath.NewCurveByValues(byte(easing.X1), byte(easing.Y1), byte(easing.X2), byte(easing.Y2))
+}
+
+// rotationDegrees 2つの回転の間の角度[度]
+func rotationDegrees(a, b *mmath.MQuaternion) float64 {
+	dot := math.Abs(a.Dot(b))
+	return 2 * math.Acos(min(1, dot)) * 180 / math.Pi
+}
+
+// interpolationError 前後のキーフレームで線形補間した値と、実際の値との誤差（位置・回転角度[度]）
+func interpolationError(
+	positions []*mmath.MVec3,
+	rotations []*mmath.MQuaternion,
+	prevIndex, nextIndex, index int,
+) (positionError, rotationError float64) {
+	t := float64(index-prevIndex) / float64(nextIndex-prevIndex)
+
+	positionError = positions[prevIndex].Lerp(positions[nextIndex], t).Distance(positions[index])
+
+	rotationError = rotationDegrees(rotations[prevIndex].Slerp(rotations[nextIndex], t), rotations[index])
+
+	return positionError, rotationError
+}
+
+// toleranceRatio 許容誤差に対する誤差の割合（許容誤差0の場合は誤差があれば必ず超過）
+func toleranceRatio(value, tolerance float64) float64 {
+	if tolerance <= 0 {
+		if value > 1e-6 {
+			return math.Inf(1)
+		}
+		return 0
+	}
+	return value / tolerance
 }
 
 func (uc *OutputUsecase) reduceMotion(
 	originalModel *pmx.PmxModel,
 	originalMotion *vmd.VmdMotion,
 	outputMotion *vmd.VmdMotion,
+	boneNameMap map[string]string,
 	outputBoneFlags [][]entity.OutputBoneFlag,
-	reducedBoneFrames []*vmd.BoneNameFrames,
+	additiveWeights [][]float64,
+	blendWeights [][]float64,
+	reducedFrames [][]bool,
+	reducedCurves [][]*reducedBoneCurves,
 	incrementCompletedCount func(),
 	isTerminate func() bool,
 ) (reducedMotion *vmd.VmdMotion, err error) {
@@ -219,21 +786,32 @@ func (uc *OutputUsecase) reduceMotion(
 				return nil, merr.NewTerminateError("manual terminate")
 			}
 
-			if (outputFlag == entity.OutputBoneFlagReduce && reducedBoneFrames[boneIndex].Contains(float32(f))) ||
+			if (outputFlag == entity.OutputBoneFlagReduce && reducedFrames[boneIndex][f]) ||
 				outputFlag == entity.OutputBoneFlagBake {
-				// 間引き出力対象で間引き後のフレームに含まれる場合、または焼き込み出力対象の場合、処理継続
+				// 間引き出力対象で間引き後のフレームの場合、または焼き込み出力対象の場合、処理継続
 				outputBf := outputMotion.BoneFrames.Get(boneName).Get(float32(f))
 
 				bf := vmd.NewBoneFrame(float32(f))
 				bf.Position = outputBf.FilledPosition().Copy() // 位置を保存
 				bf.Rotation = outputBf.FilledRotation().Copy() // 回転を保存
 				bf.Curves = nil
+				if reducedCurves[boneIndex] != nil && reducedCurves[boneIndex][f] != nil &&
+					isCurveSegmentIntact(reducedMotion.BoneFrames.Get(boneName), reducedCurves[boneIndex][f].startFrame, f) {
+					// 補間曲線で間引いた場合、前のキーフレームからの補間曲線を設定
+					bf.Curves = reducedCurves[boneIndex][f].curves
+				}
 
 				bone, err := originalModel.Bones.GetByName(boneName)
 				if err != nil {
 					continue
 				}
 
+				// 加算焼き込み・ブレンドの場合、元モーションのキーフレームと合成する
+				bf.Position, bf.Rotation = weightedBoneFrame(
+					originalBoneFrames(originalMotion, boneNameMap, boneName).Get(float32(f)), bf.Position, bf.Rotation,
+					bone.HasDynamicPhysics(), frameWeight(additiveWeights, boneIndex, f, -1),
+					frameWeight(blendWeights, boneIndex, f, 1))
+
 				// 物理ボーンの場合、物理無効で登録
 				if bone.HasDynamicPhysics() {
 					bf.DisablePhysics = true
@@ -254,18 +832,293 @@ func (uc *OutputUsecase) reduceMotion(
 	return reducedMotion, nil
 }
 
+// isCurveSegmentIntact 補間曲線を求めた区間の開始にキーフレームがあり、区間の途中にキーフレームが無いか
+//   - 他の出力設定や元モーションのキーフレームが区間の途中にある場合、求めた補間曲線は前のキーフレームからの補間にならない
+func isCurveSegmentIntact(boneFrames *vmd.BoneNameFrames, startFrame, endFrame int) bool {
+	if !boneFrames.Contains(float32(startFrame)) {
+		return false
+	}
+	for f := startFrame + 1; f < endFrame; f++ {
+		if boneFrames.Contains(float32(f)) {
+			return false
+		}
+	}
+	return true
+}
+
+// splitMotion 焼き込みセットの分割方法に従って出力モーションを分割する
+// いずれの分割方法でも、最大キーフレーム数を超える場合は追加で分割する
 func (uc *OutputUsecase) splitMotion(
 	originalModel *pmx.PmxModel,
 	originalMotion *vmd.VmdMotion,
 	outputMotionPath string,
 	reducedMotion *vmd.VmdMotion,
+	records []*entity.OutputRecord,
+	outputSplit *entity.OutputSplit,
+	outputBoneNames map[string]string,
 	incrementCompletedCount func(),
 	isTerminate func() bool,
-) (motions []*vmd.VmdMotion, err error) {
+) (motions []*vmd.VmdMotion, files []*entity.BakeReportFile, err error) {
+	if outputSplit == nil {
+		outputSplit = entity.NewOutputSplit()
+	}
+
+	splitFrames := uc.splitFrames(originalMotion, records, outputSplit)
+	physicsEnableFrames := uc.physicsEnableFrames(originalModel, records)
+
 	motions = make([]*vmd.VmdMotion, 0)
-	var motion *vmd.VmdMotion
+	files = make([]*entity.BakeReportFile, 0)
 
-	dirPath, fileName, ext := mfile.SplitPath(outputMotionPath)
+	groups := uc.splitBoneGroups(originalModel, outputSplit)
+	for i, group := range groups {
+		// モーフ・表示/IKのキーフレームは、最後のグループ（ボーングループ毎の場合は「その他」）にだけ出力する
+		withExtraFrames := i == len(groups)-1
+
+		groupMotions, groupFiles, err := uc.splitMotionByFrames(
+			originalModel, originalMotion, outputMotionPath, reducedMotion, group, splitFrames, physicsEnableFrames,
+			outputBoneNames, withExtraFrames, incrementCompletedCount, isTerminate)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		motions = append(motions, groupMotions...)
+		files = append(files, groupFiles...)
+	}
+
+	return motions, files, nil
+}
+
+// outputBoneNames 焼き込み用のボーン名から出力するボーン名への対応表を返す
+//   - 元モデルのボーン名で出力する場合、VMDに収まらない名前は警告する
+//   - 元の名前が重複するボーンは、VMDでは区別できずに同じボーンのキーになるため警告する
+func (uc *OutputUsecase) outputBoneNames(
+	boneNameMap map[string]string, outputBoneNameType entity.OutputBoneNameType,
+) map[string]string {
+	outputBoneNames := make(map[string]string)
+	if outputBoneNameType != entity.OutputBoneNameTypeOriginal {
+		return outputBoneNames
+	}
+
+	bakeBoneNames := make(map[string][]string)
+	for bakeBoneName, originalBoneName := range boneNameMap {
+		outputBoneNames[bakeBoneName] = originalBoneName
+		bakeBoneNames[originalBoneName] = append(bakeBoneNames[originalBoneName], bakeBoneName)
+	}
+
+	for originalBoneName, names := range bakeBoneNames {
+		if length, ok := shiftJISByteLength(originalBoneName); !ok || length > boneNameByteLimit {
+			mlog.W(mi18n.T("元ボーン名出力不可", map[string]any{
+				"BoneName": originalBoneName, "Limit": boneNameByteLimit}))
+		}
+		if len(names) > 1 {
+			slices.Sort(names)
+			mlog.W(mi18n.T("元ボーン名重複", map[string]any{
+				"BoneName": originalBoneName, "BakeBoneNames": strings.Join(names, ", ")}))
+		}
+	}
+
+	return outputBoneNames
+}
+
+// appendExtraFrames 元モーションのモーフ・表示/IKのキーフレームのうち、区間 [startFrame, endFrame] のものを追加し、追加件数を返す
+//   - 区間の開始には、その時点の状態をキーフレームとして追加する
+//   - 後ろに続く区間がある場合は、区間の終了にも状態を追加し、次の区間への補間を切らさない
+func appendExtraFrames(motion, originalMotion *vmd.VmdMotion, startFrame, endFrame float32, hasNext bool) int {
+	count := 0
+
+	for _, morphName := range originalMotion.MorphFrames.Names() {
+		morphFrames := originalMotion.MorphFrames.Get(morphName)
+
+		for _, f := range []float32{startFrame, endFrame} {
+			if f == endFrame && !hasNext {
+				continue
+			}
+			mf := vmd.NewMorphFrame(f)
+			mf.Ratio = morphFrames.Get(f).Ratio
+			motion.AppendMorphFrame(morphName, mf)
+			count++
+		}
+
+		morphFrames.ForEach(func(frame float32, value *vmd.MorphFrame) bool {
+			if frame <= startFrame || frame > endFrame || (frame == endFrame && hasNext) {
+				// 状態として追加済み、または区間外
+				return true
+			}
+			mf := vmd.NewMorphFrame(frame)
+			mf.Ratio = value.Ratio
+			motion.AppendMorphFrame(morphName, mf)
+			count++
+			return true
+		})
+	}
+
+	// 表示/IKは補間しないため、区間の開始の状態と区間内のキーフレームのみ
+	//   - 区間の開始より前にキーフレームが無い場合は、開始の状態を追加しない（初期状態のまま）
+	var startIkFrame *vmd.IkFrame
+	startIkFrameIndex := float32(0)
+	originalMotion.IkFrames.ForEach(func(frame float32, value *vmd.IkFrame) bool {
+		if frame <= startFrame {
+			if startIkFrame == nil || frame > startIkFrameIndex {
+				startIkFrame = value
+				startIkFrameIndex = frame
+			}
+			return true
+		}
+		if frame > endFrame {
+			return true
+		}
+		motion.AppendIkFrame(copyIkFrame(value, frame))
+		count++
+		return true
+	})
+	if startIkFrame != nil {
+		motion.AppendIkFrame(copyIkFrame(startIkFrame, startFrame))
+		count++
+	}
+
+	return count
+}
+
+// copyIkFrame 表示/IKのキーフレームを指定フレームに複製
+func copyIkFrame(ikf *vmd.IkFrame, frame float32) *vmd.IkFrame {
+	copied := vmd.NewIkFrame(frame)
+	copied.Visible = ikf.Visible
+	for _, ik := range ikf.IkList {
+		ikEnabled := vmd.NewIkEnableFrame(frame)
+		ikEnabled.BoneName = ik.BoneName
+		ikEnabled.Enabled = ik.Enabled
+		copied.IkList = append(copied.IkList, ikEnabled)
+	}
+	return copied
+}
+
+// splitBoneGroup 1グループ分の出力対象ボーン
+type splitBoneGroup struct {
+	name      string   // グループ名（ファイル名に付与、空の場合は付与しない）
+	boneNames []string // 出力対象ボーン名
+}
+
+// splitBoneGroups 分割方法に応じて出力対象ボーンをグループ分けする
+func (uc *OutputUsecase) splitBoneGroups(originalModel *pmx.PmxModel, outputSplit *entity.OutputSplit) []*splitBoneGroup {
+	if outputSplit.Type != entity.OutputSplitTypeBoneGroup {
+		return []*splitBoneGroup{{name: "", boneNames: originalModel.Bones.Names()}}
+	}
+
+	physicsGroup := &splitBoneGroup{name: "physics", boneNames: make([]string, 0)}
+	ikGroup := &splitBoneGroup{name: "ik", boneNames: make([]string, 0)}
+	otherGroup := &splitBoneGroup{name: "other", boneNames: make([]string, 0)}
+
+	originalModel.Bones.ForEach(func(boneIndex int, bone *pmx.Bone) bool {
+		switch {
+		case bone.HasDynamicPhysics():
+			physicsGroup.boneNames = append(physicsGroup.boneNames, bone.Name())
+		case bone.IsIK() || len(bone.IkLinkBoneIndexes) > 0:
+			ikGroup.boneNames = append(ikGroup.boneNames, bone.Name())
+		default:
+			otherGroup.boneNames = append(otherGroup.boneNames, bone.Name())
+		}
+		return true
+	})
+
+	groups := make([]*splitBoneGroup, 0)
+	for _, group := range []*splitBoneGroup{physicsGroup, ikGroup, otherGroup} {
+		if len(group.boneNames) > 0 {
+			groups = append(groups, group)
+		}
+	}
+
+	return groups
+}
+
+// splitFrames 分割方法に応じて、新しいファイルを開始するフレームを求める
+func (uc *OutputUsecase) splitFrames(
+	originalMotion *vmd.VmdMotion,
+	records []*entity.OutputRecord,
+	outputSplit *entity.OutputSplit,
+) map[float32]bool {
+	splitFrames := make(map[float32]bool)
+
+	switch outputSplit.Type {
+	case entity.OutputSplitTypeInterval:
+		if outputSplit.Interval >= 1 {
+			for f := float32(outputSplit.Interval); f < originalMotion.MaxFrame(); f += float32(outputSplit.Interval) {
+				splitFrames[float32(int(f))] = true
+			}
+		}
+	case entity.OutputSplitTypeFrames:
+		for _, f := range outputSplit.Frames {
+			splitFrames[float32(int(f))] = true
+		}
+	case entity.OutputSplitTypeRecord:
+		for _, record := range records {
+			splitFrames[record.StartFrame] = true
+			splitFrames[record.EndFrame+1] = true
+		}
+	}
+
+	return splitFrames
+}
+
+// physicsEnableFrames 物理ボーン毎に、物理有効に戻すフレーム
+//   - 出力設定の区間終了（ブレンドアウト区間の終わり）で物理演算に戻す
+//   - 直後のフレームから同じボーンを出力する出力設定が続く場合は、物理無効のまま続ける
+func (uc *OutputUsecase) physicsEnableFrames(
+	originalModel *pmx.PmxModel, records []*entity.OutputRecord,
+) map[string]map[float32]bool {
+	recordBoneNames := make([][]string, len(records))
+	for i, record := range records {
+		recordBoneNames[i] = record.ItemBoneNames()
+	}
+
+	enableFrames := make(map[string]map[float32]bool)
+	for i, record := range records {
+		for _, boneName := range recordBoneNames[i] {
+			bone, err := originalModel.Bones.GetByName(boneName)
+			if err != nil || !bone.HasDynamicPhysics() {
+				continue
+			}
+
+			isContinued := false
+			for j, nextRecord := range records {
+				if nextRecord.StartFrame <= record.EndFrame+1 && record.EndFrame+1 <= nextRecord.EndFrame &&
+					slices.Contains(recordBoneNames[j], boneName) {
+					isContinued = true
+					break
+				}
+			}
+			if isContinued {
+				continue
+			}
+
+			if _, ok := enableFrames[boneName]; !ok {
+				enableFrames[boneName] = make(map[float32]bool)
+			}
+			enableFrames[boneName][record.EndFrame] = true
+		}
+	}
+
+	return enableFrames
+}
+
+// splitMotionByFrames 指定ボーンのキーフレームを、分割フレームと最大キーフレーム数で分割する
+func (uc *OutputUsecase) splitMotionByFrames(
+	originalModel *pmx.PmxModel,
+	originalMotion *vmd.VmdMotion,
+	outputMotionPath string,
+	reducedMotion *vmd.VmdMotion,
+	group *splitBoneGroup,
+	splitFrames map[float32]bool,
+	physicsEnableFrames map[string]map[float32]bool,
+	outputBoneNames map[string]string,
+	withExtraFrames bool,
+	incrementCompletedCount func(),
+	isTerminate func() bool,
+) (motions []*vmd.VmdMotion, files []*entity.BakeReportFile, err error) {
+	motions = make([]*vmd.VmdMotion, 0)
+	startFrames := make([]int, 0)
+	filesKeys := make([]map[string]map[float32]struct{}, 0)
+	var motion *vmd.VmdMotion
+	var fileKeys map[string]map[float32]struct{}
 
 	logInterval := 100000
 	frameCount := 0
@@ -274,16 +1127,18 @@ func (uc *OutputUsecase) splitMotion(
 
 	for f := float32(0); f < originalMotion.MaxFrame(); f++ {
 		if isTerminate() {
-			return nil, merr.NewTerminateError("manual terminate")
+			return nil, nil, merr.NewTerminateError("manual terminate")
 		}
 
-		if len(motions) == 0 || prevFrameTotalCount+frameCount > vmd.MAX_BONE_FRAMES {
-			// 最大登録数を超える場合、新規モーションを作成
-
+		if len(motions) == 0 || splitFrames[f] || prevFrameTotalCount+frameCount > vmd.MAX_BONE_FRAMES {
+			// 分割フレームの場合、または最大登録数を超える場合、新規モーションを作成
 			motion = vmd.NewVmdMotion("")
 			motion.SetName(fmt.Sprintf("%s_baked", originalModel.Name()))
-			motion.SetPath(fmt.Sprintf("%s%s_%02d_%04d%s", dirPath, fileName, len(motions)+1, int(f), ext))
+			fileKeys = make(map[string]map[float32]struct{})
+
 			motions = append(motions, motion)
+			startFrames = append(startFrames, int(f))
+			filesKeys = append(filesKeys, fileKeys)
 
 			prevFrameTotalCount = 0
 		} else {
@@ -291,7 +1146,7 @@ func (uc *OutputUsecase) splitMotion(
 		}
 
 		frameCount = 0
-		for _, boneName := range originalModel.Bones.Names() {
+		for _, boneName := range group.boneNames {
 			incrementCompletedCount()
 
 			if reducedMotion.BoneFrames.Get(boneName).Contains(f) {
@@ -302,31 +1157,102 @@ func (uc *OutputUsecase) splitMotion(
 
 				bf := reducedMotion.BoneFrames.Get(boneName).Get(f)
 
+				// 出力するボーン名（改名していない場合はそのまま）
+				outputBoneName := boneName
+				if name, ok := outputBoneNames[boneName]; ok {
+					outputBoneName = name
+				}
+
+				isPhysicsEnable := physicsEnableFrames[boneName][f]
 				if bone.HasDynamicPhysics() {
-					// 物理ボーンの場合、物理無効で登録
-					bf.DisablePhysics = true
+					// 物理ボーンの場合、ブレンドアウト区間の終わりまで物理無効で登録し、そこで物理有効に戻す
+					bf.DisablePhysics = !isPhysicsEnable
 				}
 
-				motion.AppendBoneFrame(boneName, bf)
+				motion.AppendBoneFrame(outputBoneName, bf)
+				appendFileKey(fileKeys, boneName, f)
+				frameCount++
 
-				// 補間曲線分割済みの次のキーフレ取得して、出力モーションに追加
-				nextFrame := reducedMotion.BoneFrames.Get(boneName).NextFrame(f + 1)
-				nextBf := reducedMotion.BoneFrames.Get(boneName).Get(nextFrame)
+				if !bone.HasDynamicPhysics() || !isPhysicsEnable {
+					// 補間曲線分割済みの次のキーフレ取得して、出力モーションに追加
+					nextFrame := reducedMotion.BoneFrames.Get(boneName).NextFrame(f + 1)
+					nextBf := reducedMotion.BoneFrames.Get(boneName).Get(nextFrame)
 
-				if bone.HasDynamicPhysics() {
-					// 物理ボーンの場合、物理有効で登録
-					nextBf.DisablePhysics = false
+					if bone.HasDynamicPhysics() {
+						nextBf.DisablePhysics = !physicsEnableFrames[boneName][nextFrame]
+					}
+
+					motion.AppendBoneFrame(outputBoneName, nextBf)
+					appendFileKey(fileKeys, boneName, nextFrame)
+					frameCount++
 				}
 
-				motion.AppendBoneFrame(boneName, nextBf)
+				if (prevFrameTotalCount+frameCount)%logInterval == 0 {
+					mlog.I(fmt.Sprintf(mi18n.T("--- [%02d][%06d] キーフレーム焼き込み処理中 ..."), (prevFrameTotalCount+frameCount)/logInterval, maxFrameCount))
+				}
 			}
+		}
+	}
 
-			frameCount += 2
-			if prevFrameTotalCount+frameCount%logInterval == 0 {
-				mlog.I(fmt.Sprintf(mi18n.T("--- [%02d][%06d] キーフレーム焼き込み処理中 ..."), frameCount/logInterval, maxFrameCount))
+	// ボーン以外のキーフレームを、ボーンと同じ区間で分割して追加する
+	extraCounts := make([]int, len(motions))
+	if withExtraFrames {
+		for i, motion := range motions {
+			startFrame := float32(startFrames[i])
+			endFrame := originalMotion.MaxFrame()
+			if i < len(motions)-1 {
+				endFrame = float32(startFrames[i+1] - 1)
 			}
+			extraCounts[i] = appendExtraFrames(motion, originalMotion, startFrame, endFrame, i < len(motions)-1)
 		}
 	}
 
-	return motions, nil
+	// キーフレームの無いファイルは出力しない（ボーングループ以外で全て空の場合は1件だけ残す）
+	dirPath, fileName, ext := mfile.SplitPath(outputMotionPath)
+	if group.name != "" {
+		fileName = fmt.Sprintf("%s_%s", fileName, group.name)
+	}
+
+	outputMotions := make([]*vmd.VmdMotion, 0, len(motions))
+	files = make([]*entity.BakeReportFile, 0, len(motions))
+	for i, motion := range motions {
+		isKeepEmpty := group.name == "" && len(outputMotions) == 0 && i == len(motions)-1
+		if len(filesKeys[i]) == 0 && extraCounts[i] == 0 && !isKeepEmpty {
+			continue
+		}
+
+		motion.SetPath(fmt.Sprintf("%s%s_%02d_%04d%s", dirPath, fileName, len(outputMotions)+1, startFrames[i], ext))
+		outputMotions = append(outputMotions, motion)
+
+		file := newBakeReportFile(motion, filesKeys[i])
+		file.Group = group.name
+		files = append(files, file)
+	}
+
+	return outputMotions, files, nil
+}
+
+// appendFileKey 分割ファイルに出力したキーフレームを記録
+func appendFileKey(fileKeys map[string]map[float32]struct{}, boneName string, frame float32) {
+	if _, ok := fileKeys[boneName]; !ok {
+		fileKeys[boneName] = make(map[float32]struct{})
+	}
+	fileKeys[boneName][frame] = struct{}{}
+}
+
+// newBakeReportFile 分割ファイル1件分のレポートを生成
+func newBakeReportFile(motion *vmd.VmdMotion, fileKeys map[string]map[float32]struct{}) *entity.BakeReportFile {
+	file := &entity.BakeReportFile{
+		Path:          motion.Path(),
+		StartFrame:    motion.MinFrame(),
+		EndFrame:      motion.MaxFrame(),
+		BoneKeyCounts: make(map[string]int, len(fileKeys)),
+	}
+
+	for boneName, frames := range fileKeys {
+		file.BoneKeyCounts[boneName] = len(frames)
+		file.KeyCount += len(frames)
+	}
+
+	return file
 }
@@ -0,0 +1,654 @@
+package usecase
+
+import (
+	"maps"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/miu200521358/bone_baker/pkg/domain/entity"
+	"github.com/miu200521358/bone_baker/pkg/testutil"
+	"github.com/miu200521358/mlib_go/pkg/domain/mmath"
+	"github.com/miu200521358/mlib_go/pkg/domain/pmx"
+	"github.com/miu200521358/mlib_go/pkg/domain/vmd"
+)
+
+// newCheckedOutputRecord 指定ボーンにチェックを入れた出力設定レコード
+func newCheckedOutputRecord(
+	model *pmx.PmxModel, startFrame, endFrame float32, reduce bool, boneNames ...string,
+) *entity.OutputRecord {
+	record := entity.NewOutputRecord(startFrame, endFrame, model)
+	record.Reduce = reduce
+
+	var check func(items []*entity.OutputItem)
+	check = func(items []*entity.OutputItem) {
+		for _, item := range items {
+			item.Checked = slices.Contains(boneNames, item.BoneName)
+			check(item.Children)
+		}
+	}
+	check(record.Tree.Items)
+
+	return record
+}
+
+func TestOutputUsecase_GetBakedBoneFlags(t *testing.T) {
+	model := testutil.NewModel()
+	motion := testutil.NewMotion()
+
+	type frameFlag struct {
+		boneName string
+		frame    int
+		flag     entity.OutputBoneFlag
+	}
+
+	tests := []struct {
+		name              string
+		records           []*entity.OutputRecord
+		wantFrameCount    int
+		wantContainReduce bool
+		want              []frameFlag
+	}{
+		{
+			name:           "開始フレーム0から焼き込み",
+			records:        []*entity.OutputRecord{newCheckedOutputRecord(model, 0, 3, false, testutil.BoneHair1)},
+			wantFrameCount: 11,
+			want: []frameFlag{
+				{testutil.BoneHair1, 0, entity.OutputBoneFlagBake},
+				{testutil.BoneHair1, 3, entity.OutputBoneFlagBake},
+				{testutil.BoneHair1, 4, entity.OutputBoneFlagEmpty},
+				{testutil.BoneCenter, 0, entity.OutputBoneFlagOriginal},
+				{testutil.BoneCenter, 5, entity.OutputBoneFlagEmpty},
+				{testutil.BoneCenter, 10, entity.OutputBoneFlagOriginal},
+				{testutil.BoneUpperBody, 5, entity.OutputBoneFlagOriginal},
+			},
+		},
+		{
+			name: "重複する区間は後のレコードを優先",
+			records: []*entity.OutputRecord{
+				newCheckedOutputRecord(model, 0, 10, false, testutil.BoneHair1),
+				newCheckedOutputRecord(model, 5, 15, true, testutil.BoneHair1),
+			},
+			wantFrameCount:    16,
+			wantContainReduce: true,
+			want: []frameFlag{
+				{testutil.BoneHair1, 4, entity.OutputBoneFlagBake},
+				{testutil.BoneHair1, 5, entity.OutputBoneFlagReduce},
+				{testutil.BoneHair1, 10, entity.OutputBoneFlagReduce},
+				{testutil.BoneHair1, 15, entity.OutputBoneFlagReduce},
+			},
+		},
+		{
+			name:           "表示枠のないボーンは出力対象外",
+			records:        []*entity.OutputRecord{newCheckedOutputRecord(model, 0, 5, true, testutil.BoneHair2)},
+			wantFrameCount: 11,
+			want: []frameFlag{
+				{testutil.BoneHair2, 0, entity.OutputBoneFlagEmpty},
+				{testutil.BoneHair2, 5, entity.OutputBoneFlagEmpty},
+			},
+		},
+		{
+			name:           "元モーションのキーより出力設定を優先",
+			records:        []*entity.OutputRecord{newCheckedOutputRecord(model, 0, 10, false, testutil.BoneCenter)},
+			wantFrameCount: 11,
+			want: []frameFlag{
+				{testutil.BoneCenter, 0, entity.OutputBoneFlagBake},
+				{testutil.BoneCenter, 5, entity.OutputBoneFlagBake},
+				{testutil.BoneCenter, 10, entity.OutputBoneFlagBake},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewOutputUsecase()
+			flags, isContainsReduce := uc.GetBakedBoneFlags(model, motion, tt.records)
+
+			if isContainsReduce != tt.wantContainReduce {
+				t.Errorf("isContainsReduce = %v, want %v", isContainsReduce, tt.wantContainReduce)
+			}
+
+			if len(flags) != model.Bones.Length() {
+				t.Fatalf("len(flags) = %d, want %d", len(flags), model.Bones.Length())
+			}
+
+			for _, w := range tt.want {
+				bone, err := model.Bones.GetByName(w.boneName)
+				if err != nil {
+					t.Fatalf("bone %s not found: %v", w.boneName, err)
+				}
+
+				if len(flags[bone.Index()]) != tt.wantFrameCount {
+					t.Fatalf("len(flags[%s]) = %d, want %d", w.boneName, len(flags[bone.Index()]), tt.wantFrameCount)
+				}
+
+				if got := flags[bone.Index()][w.frame]; got != w.flag {
+					t.Errorf("flags[%s][%d] = %d, want %d", w.boneName, w.frame, got, w.flag)
+				}
+			}
+		})
+	}
+}
+
+func TestOutputUsecase_splitFrames(t *testing.T) {
+	model := testutil.NewModel()
+	motion := testutil.NewMotion()
+
+	tests := []struct {
+		name        string
+		records     []*entity.OutputRecord
+		outputSplit *entity.OutputSplit
+		want        []float32
+	}{
+		{
+			name:        "最大キーフレーム数のみ",
+			outputSplit: &entity.OutputSplit{Type: entity.OutputSplitTypeMaxFrames},
+			want:        []float32{},
+		},
+		{
+			name:        "一定フレーム間隔",
+			outputSplit: &entity.OutputSplit{Type: entity.OutputSplitTypeInterval, Interval: 4},
+			want:        []float32{4, 8},
+		},
+		{
+			name:        "間隔1未満は分割しない",
+			outputSplit: &entity.OutputSplit{Type: entity.OutputSplitTypeInterval, Interval: 0},
+			want:        []float32{},
+		},
+		{
+			name:        "指定フレーム",
+			outputSplit: &entity.OutputSplit{Type: entity.OutputSplitTypeFrames, Frames: []float32{3, 7.5}},
+			want:        []float32{3, 7},
+		},
+		{
+			name: "出力設定の区間毎",
+			records: []*entity.OutputRecord{
+				newCheckedOutputRecord(model, 0, 3, false),
+				newCheckedOutputRecord(model, 2, 6, false),
+			},
+			outputSplit: &entity.OutputSplit{Type: entity.OutputSplitTypeRecord},
+			want:        []float32{0, 2, 4, 7},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewOutputUsecase()
+			got := uc.splitFrames(motion, tt.records, tt.outputSplit)
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("splitFrames = %v, want %v", got, tt.want)
+			}
+			for _, f := range tt.want {
+				if !got[f] {
+					t.Errorf("splitFrames = %v, want contains %v", got, f)
+				}
+			}
+		})
+	}
+}
+
+func TestOutputUsecase_splitMotionByFrames_SparseFrames(t *testing.T) {
+	model := testutil.NewModel()
+
+	// 最大キーフレーム数/ボーン数 を超えるフレーム数に、センターだけ 1000F 毎のキーフレームを持つ
+	maxFrame := float32(vmd.MAX_BONE_FRAMES/model.Bones.Length() + 1000)
+	originalMotion := vmd.NewVmdMotion("")
+	originalMotion.AppendBoneFrame(testutil.BoneCenter, vmd.NewBoneFrame(maxFrame))
+
+	reducedMotion := vmd.NewVmdMotion("")
+	for f := float32(0); f <= maxFrame; f += 1000 {
+		reducedMotion.AppendBoneFrame(testutil.BoneCenter, vmd.NewBoneFrame(f))
+	}
+
+	group := &splitBoneGroup{name: "", boneNames: model.Bones.Names()}
+	motions, files, err := NewOutputUsecase().splitMotionByFrames(
+		model, originalMotion, "fixture.vmd", reducedMotion, group, map[float32]bool{},
+		map[string]map[float32]bool{}, map[string]string{}, false, func() {}, func() bool { return false })
+	if err != nil {
+		t.Fatalf("splitMotionByFrames error: %v", err)
+	}
+
+	// 登録したキーフレーム数は最大キーフレーム数に満たないため、分割しない
+	if len(motions) != 1 || len(files) != 1 {
+		t.Errorf("motions = %d, files = %d, want 1", len(motions), len(files))
+	}
+}
+
+func TestAppendExtraFrames(t *testing.T) {
+	// 0F: 0.0 → 10F: 1.0 のモーフを 0-4F / 5-10F に分割
+	originalMotion := testutil.NewMotion()
+	for _, key := range []struct {
+		frame float32
+		ratio float64
+	}{{0, 0}, {10, 1}} {
+		mf := vmd.NewMorphFrame(key.frame)
+		mf.Ratio = key.ratio
+		originalMotion.AppendMorphFrame("あ", mf)
+	}
+
+	tests := []struct {
+		name       string
+		startFrame float32
+		endFrame   float32
+		hasNext    bool
+		want       map[float32]float64
+	}{
+		{name: "前半（終了に状態を追加）", startFrame: 0, endFrame: 4, hasNext: true, want: map[float32]float64{0: 0, 4: 0.4}},
+		{name: "後半（開始に状態を追加）", startFrame: 5, endFrame: 10, hasNext: false, want: map[float32]float64{5: 0.5, 10: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			motion := vmd.NewVmdMotion("")
+			count := appendExtraFrames(motion, originalMotion, tt.startFrame, tt.endFrame, tt.hasNext)
+			if count != len(tt.want) {
+				t.Errorf("count = %d, want %d", count, len(tt.want))
+			}
+
+			morphFrames := motion.MorphFrames.Get("あ")
+			for f, ratio := range tt.want {
+				if !morphFrames.Contains(f) {
+					t.Errorf("frame %v not found", f)
+					continue
+				}
+				if got := morphFrames.Get(f).Ratio; math.Abs(got-ratio) > 1e-6 {
+					t.Errorf("ratio[%v] = %f, want %f", f, got, ratio)
+				}
+			}
+		})
+	}
+}
+
+func TestAppendExtraFrames_IkFrames(t *testing.T) {
+	// 3F: 非表示・左足ＩＫ無効 → 7F: 表示・左足ＩＫ有効
+	originalMotion := vmd.NewVmdMotion("")
+	for _, key := range []struct {
+		frame   float32
+		enabled bool
+	}{{3, false}, {7, true}} {
+		ikf := vmd.NewIkFrame(key.frame)
+		ikf.Visible = key.enabled
+		ik := vmd.NewIkEnableFrame(key.frame)
+		ik.BoneName = "左足ＩＫ"
+		ik.Enabled = key.enabled
+		ikf.IkList = append(ikf.IkList, ik)
+		originalMotion.AppendIkFrame(ikf)
+	}
+
+	tests := []struct {
+		name       string
+		startFrame float32
+		endFrame   float32
+		want       map[float32]bool // フレーム → 表示・IK有効
+	}{
+		{name: "開始より前にキーフレーム無し", startFrame: 0, endFrame: 4, want: map[float32]bool{3: false}},
+		{name: "開始の状態と区間内のキーフレーム", startFrame: 5, endFrame: 10, want: map[float32]bool{5: false, 7: true}},
+		{name: "区間内にキーフレーム無し", startFrame: 8, endFrame: 10, want: map[float32]bool{8: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			motion := vmd.NewVmdMotion("")
+			count := appendExtraFrames(motion, originalMotion, tt.startFrame, tt.endFrame, false)
+			if count != len(tt.want) {
+				t.Errorf("count = %d, want %d", count, len(tt.want))
+			}
+
+			got := make(map[float32]bool)
+			motion.IkFrames.ForEach(func(frame float32, value *vmd.IkFrame) bool {
+				got[frame] = value.Visible
+				if len(value.IkList) != 1 || value.IkList[0].BoneName != "左足ＩＫ" || value.IkList[0].Enabled != value.Visible {
+					t.Errorf("IkList[%v] = %v, want 左足ＩＫ %v", frame, value.IkList, value.Visible)
+				}
+				return true
+			})
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("ik frames = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutputUsecase_additiveWeights(t *testing.T) {
+	model := testutil.NewModel()
+
+	// 0-10F を加算焼き込み、3-5F のみ置き換えで焼き込み
+	additiveRecord := newCheckedOutputRecord(model, 0, 10, false, testutil.BoneHair1)
+	additiveRecord.Additive = true
+	additiveRecord.AdditiveWeight = 0.5
+	overrideRecord := newCheckedOutputRecord(model, 3, 5, false, testutil.BoneHair1)
+
+	uc := NewOutputUsecase()
+	weights := uc.additiveWeights(model, []*entity.OutputRecord{additiveRecord, overrideRecord}, 11)
+
+	centerBone, _ := model.Bones.GetByName(testutil.BoneCenter)
+	if weights[centerBone.Index()] != nil {
+		t.Errorf("center weights = %v, want nil", weights[centerBone.Index()])
+	}
+
+	hairBone, _ := model.Bones.GetByName(testutil.BoneHair1)
+	want := []float64{0.5, 0.5, 0.5, -1, -1, -1, 0.5, 0.5, 0.5, 0.5, 0.5}
+	if got := weights[hairBone.Index()]; !slices.Equal(got, want) {
+		t.Errorf("hair weights = %v, want %v", got, want)
+	}
+}
+
+func TestOutputUsecase_bakeMotion_RenamedPhysicsBone(t *testing.T) {
+	// 焼き込み用に改名した髪1を加算焼き込みする（元モーションのキーフレームは元の名前のまま）
+	model := testutil.NewModel()
+	hairBone, _ := model.Bones.GetByName(testutil.BoneHair1)
+	bakeBoneName := "BB2_" + testutil.BoneHair1
+	hairBone.SetName(bakeBoneName)
+	model.Bones.UpdateNameIndexes()
+	boneNameMap := map[string]string{bakeBoneName: testutil.BoneHair1}
+
+	originalMotion := testutil.NewMotion()
+	originalBf := vmd.NewBoneFrame(5)
+	originalBf.Position = &mmath.MVec3{X: 1}
+	originalMotion.AppendBoneFrame(testutil.BoneHair1, originalBf)
+
+	outputMotion := vmd.NewVmdMotion("")
+	outputBf := vmd.NewBoneFrame(5)
+	outputBf.Position = &mmath.MVec3{X: 1, Y: 2}
+	outputMotion.AppendBoneFrame(bakeBoneName, outputBf)
+
+	record := newCheckedOutputRecord(model, 0, 10, false, bakeBoneName)
+	record.Additive = true
+	record.AdditiveWeight = 0.5
+	records := []*entity.OutputRecord{record}
+
+	outputBoneFlags := make([][]entity.OutputBoneFlag, model.Bones.Length())
+	for boneIndex := range outputBoneFlags {
+		outputBoneFlags[boneIndex] = make([]entity.OutputBoneFlag, 11)
+	}
+	for f := range outputBoneFlags[hairBone.Index()] {
+		outputBoneFlags[hairBone.Index()][f] = entity.OutputBoneFlagBake
+	}
+
+	uc := NewOutputUsecase()
+	bakedMotion, err := uc.bakeMotion(model, originalMotion, outputMotion, boneNameMap, outputBoneFlags,
+		uc.additiveWeights(model, records, 11), uc.blendWeights(model, records, 11),
+		func() {}, func() bool { return false })
+	if err != nil {
+		t.Fatalf("bakeMotion error: %v", err)
+	}
+
+	// 物理ボーンは元の名前のキーフレームに、焼き込み結果の半分を加算する
+	want := &mmath.MVec3{X: 1.5, Y: 1}
+	if got := bakedMotion.BoneFrames.Get(bakeBoneName).Get(5).FilledPosition(); !got.NearEquals(want, 1e-6) {
+		t.Errorf("position = %v, want %v", got, want)
+	}
+}
+
+func TestLayerBoneFrame(t *testing.T) {
+	tests := []struct {
+		name         string
+		original     *vmd.BoneFrame
+		position     *mmath.MVec3
+		rotation     *mmath.MQuaternion
+		isPhysics    bool
+		weight       float64
+		wantPosition *mmath.MVec3
+		wantRotation *mmath.MQuaternion
+	}{
+		{
+			name: "物理ボーン（初期姿勢からの変化量を重ねる）",
+			original: func() *vmd.BoneFrame {
+				bf := vmd.NewBoneFrame(0)
+				bf.Position = &mmath.MVec3{X: 1}
+				return bf
+			}(),
+			position:     &mmath.MVec3{Y: 2},
+			rotation:     mmath.NewMQuaternionFromDegrees(0, 90, 0),
+			isPhysics:    true,
+			weight:       0.5,
+			wantPosition: &mmath.MVec3{X: 1, Y: 1},
+			wantRotation: mmath.NewMQuaternionFromDegrees(0, 45, 0),
+		},
+		{
+			name: "物理以外のボーン（元モーションからの変化量を重ねる）",
+			original: func() *vmd.BoneFrame {
+				bf := vmd.NewBoneFrame(0)
+				bf.Position = &mmath.MVec3{X: 1}
+				bf.Rotation = mmath.NewMQuaternionFromDegrees(0, 30, 0)
+				return bf
+			}(),
+			position:     &mmath.MVec3{X: 1, Y: 2},
+			rotation:     mmath.NewMQuaternionFromDegrees(0, 90, 0),
+			isPhysics:    false,
+			weight:       0.5,
+			wantPosition: &mmath.MVec3{X: 1, Y: 1},
+			wantRotation: mmath.NewMQuaternionFromDegrees(0, 60, 0),
+		},
+		{
+			name:         "割合0は元モーションのまま",
+			original:     vmd.NewBoneFrame(0),
+			position:     &mmath.MVec3{Y: 2},
+			rotation:     mmath.NewMQuaternionFromDegrees(0, 90, 0),
+			isPhysics:    true,
+			weight:       0,
+			wantPosition: &mmath.MVec3{},
+			wantRotation: mmath.NewMQuaternion(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			position, rotation := layerBoneFrame(tt.original, tt.position, tt.rotation, tt.isPhysics, tt.weight)
+			if !position.NearEquals(tt.wantPosition, 1e-6) {
+				t.Errorf("position = %v, want %v", position, tt.wantPosition)
+			}
+			if !rotation.NearEquals(tt.wantRotation, 1e-6) {
+				t.Errorf("rotation = %v, want %v", rotation, tt.wantRotation)
+			}
+		})
+	}
+}
+
+func TestOutputUsecase_blendWeights(t *testing.T) {
+	model := testutil.NewModel()
+
+	record := newCheckedOutputRecord(model, 0, 10, false, testutil.BoneCenter, testutil.BoneHair1)
+	record.BlendInFrames = 2
+	record.BlendOutFrames = 3
+
+	uc := NewOutputUsecase()
+	weights := uc.blendWeights(model, []*entity.OutputRecord{record}, 11)
+
+	upperBone, _ := model.Bones.GetByName(testutil.BoneUpperBody)
+	if got := frameWeight(weights, upperBone.Index(), 0, 1); got != 1 {
+		t.Errorf("upper body weight = %f, want 1", got)
+	}
+
+	// 物理ボーンも物理演算の姿勢から焼き込み結果へ補間する
+	want := []float64{1.0 / 3, 2.0 / 3, 1, 1, 1, 1, 1, 1, 3.0 / 4, 2.0 / 4, 1.0 / 4}
+	for _, boneName := range []string{testutil.BoneCenter, testutil.BoneHair1} {
+		bone, _ := model.Bones.GetByName(boneName)
+		for f, w := range want {
+			if got := frameWeight(weights, bone.Index(), f, 1); math.Abs(got-w) > 1e-6 {
+				t.Errorf("%s weight[%d] = %f, want %f", boneName, f, got, w)
+			}
+		}
+	}
+}
+
+func TestOutputUsecase_physicsEnableFrames(t *testing.T) {
+	model := testutil.NewModel()
+
+	// 髪1は 0-10F と 11-20F が続くため 20F で、髪2は 0-10F の 10F で物理有効に戻す
+	records := []*entity.OutputRecord{
+		newCheckedOutputRecord(model, 0, 10, false, testutil.BoneCenter, testutil.BoneHair1, testutil.BoneHair2),
+		newCheckedOutputRecord(model, 11, 20, false, testutil.BoneHair1),
+	}
+
+	got := NewOutputUsecase().physicsEnableFrames(model, records)
+
+	want := map[string]map[float32]bool{
+		testutil.BoneHair1: {20: true},
+		testutil.BoneHair2: {10: true},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("physicsEnableFrames = %v, want %v", got, want)
+	}
+	for boneName, frames := range want {
+		if !maps.Equal(got[boneName], frames) {
+			t.Errorf("%s frames = %v, want %v", boneName, got[boneName], frames)
+		}
+	}
+}
+
+func TestWeightedBoneFrame(t *testing.T) {
+	originalBf := vmd.NewBoneFrame(0)
+	originalBf.Position = &mmath.MVec3{X: 1}
+
+	tests := []struct {
+		name           string
+		isPhysics      bool
+		additiveWeight float64
+		blendWeight    float64
+		wantPosition   *mmath.MVec3
+		wantRotation   *mmath.MQuaternion
+	}{
+		{
+			name:           "置き換え（ブレンドなし）",
+			additiveWeight: -1,
+			blendWeight:    1,
+			wantPosition:   &mmath.MVec3{X: 1, Y: 2},
+			wantRotation:   mmath.NewMQuaternionFromDegrees(0, 90, 0),
+		},
+		{
+			name:           "置き換え（ブレンド中）",
+			additiveWeight: -1,
+			blendWeight:    0.5,
+			wantPosition:   &mmath.MVec3{X: 1, Y: 1},
+			wantRotation:   mmath.NewMQuaternionFromDegrees(0, 45, 0),
+		},
+		{
+			name:           "加算（ブレンド中）",
+			additiveWeight: 0.5,
+			blendWeight:    0.5,
+			wantPosition:   &mmath.MVec3{X: 1, Y: 0.5},
+			wantRotation:   mmath.NewMQuaternionFromDegrees(0, 22.5, 0),
+		},
+		{
+			name:           "物理ボーン置き換え（ブレンド中）",
+			isPhysics:      true,
+			additiveWeight: -1,
+			blendWeight:    0.5,
+			wantPosition:   &mmath.MVec3{X: 1, Y: 2},
+			wantRotation:   mmath.NewMQuaternionFromDegrees(0, 90, 0),
+		},
+		{
+			name:           "物理ボーン加算（ブレンド中）",
+			isPhysics:      true,
+			additiveWeight: 0.5,
+			blendWeight:    0.5,
+			wantPosition:   &mmath.MVec3{X: 1.25, Y: 1.5},
+			wantRotation:   mmath.NewMQuaternionFromDegrees(0, 67.5, 0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			position, rotation := weightedBoneFrame(originalBf, &mmath.MVec3{X: 1, Y: 2},
+				mmath.NewMQuaternionFromDegrees(0, 90, 0), tt.isPhysics, tt.additiveWeight, tt.blendWeight)
+			if !position.NearEquals(tt.wantPosition, 1e-6) {
+				t.Errorf("position = %v, want %v", position, tt.wantPosition)
+			}
+			if !rotation.NearEquals(tt.wantRotation, 1e-6) {
+				t.Errorf("rotation = %v, want %v", rotation, tt.wantRotation)
+			}
+		})
+	}
+}
+
+func TestOutputUsecase_reduceCurveSegment(t *testing.T) {
+	// 0F → 30F でイーズインアウトしながら X 方向に移動・Y 軸回転する
+	easing := entity.NewEasing(entity.EasingTypeEaseInOut)
+	positions := make([]*mmath.MVec3, 31)
+	rotations := make([]*mmath.MQuaternion, 31)
+	for i := range positions {
+		ratio := easing.Ratio(float64(i) / 30)
+		positions[i] = &mmath.MVec3{X: 10 * ratio}
+		rotations[i] = mmath.NewMQuaternionFromDegrees(0, 90*ratio, 0)
+	}
+
+	record := entity.NewOutputRecord(0, 30, testutil.NewModel())
+	uc := NewOutputUsecase()
+
+	linearCount := 0
+	uc.reduceSegment(positions, rotations, 0, 30, record, func(index int) {
+		linearCount++
+	})
+
+	curveIndexes := make([]int, 0)
+	uc.reduceCurveSegment(positions, rotations, 0, 30, record, func(startIndex, endIndex int, fit *boneCurveFit) {
+		curveIndexes = append(curveIndexes, startIndex, endIndex)
+		if fit.positionError > record.ReducePositionTolerance || fit.rotationError > record.ReduceRotationTolerance {
+			t.Errorf("fit error = (%f, %f), want within tolerance", fit.positionError, fit.rotationError)
+		}
+	})
+
+	// 補間曲線で間引いた場合は区間全体が1つの補間曲線で表せる
+	if want := []int{0, 30}; !slices.Equal(curveIndexes, want) {
+		t.Errorf("curve indexes = %v, want %v", curveIndexes, want)
+	}
+	if linearCount == 0 {
+		t.Errorf("linear count = 0, want > 0")
+	}
+}
+
+func TestOutputUsecase_reduceBoneFrames(t *testing.T) {
+	// 出力する位置が 0F → 4F で X 方向に等速移動し、5F 以降は 0 に戻る
+	frameValue := func(f int) (*mmath.MVec3, *mmath.MQuaternion) {
+		x := float64(f)
+		if f >= 5 {
+			x = 0
+		}
+		return &mmath.MVec3{X: x}, mmath.NewMQuaternion()
+	}
+
+	record := entity.NewOutputRecord(0, 10, testutil.NewModel())
+	record.Reduce = true
+	keepFrames := make([]bool, 11)
+	report := &entity.OutputReduceReport{}
+
+	uc := NewOutputUsecase()
+	uc.reduceBoneFrames(frameValue, record, keepFrames, nil, report)
+
+	if !keepFrames[0] || !keepFrames[10] {
+		t.Errorf("keepFrames = %v, want start and end kept", keepFrames)
+	}
+	if !keepFrames[4] || !keepFrames[5] {
+		t.Errorf("keepFrames = %v, want 4F and 5F kept", keepFrames)
+	}
+	if report.MaxPositionError > record.ReducePositionTolerance {
+		t.Errorf("max position error = %f, want <= %f", report.MaxPositionError, record.ReducePositionTolerance)
+	}
+}
+
+func TestIsCurveSegmentIntact(t *testing.T) {
+	tests := []struct {
+		name   string
+		frames []float32
+		want   bool
+	}{
+		{name: "区間の開始と終了のみ", frames: []float32{0, 10}, want: true},
+		{name: "区間の途中にキーフレーム", frames: []float32{0, 5, 10}, want: false},
+		{name: "区間の開始にキーフレーム無し", frames: []float32{3, 10}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			motion := vmd.NewVmdMotion("")
+			for _, f := range tt.frames {
+				motion.AppendBoneFrame(testutil.BoneHair1, vmd.NewBoneFrame(f))
+			}
+
+			if got := isCurveSegmentIntact(motion.BoneFrames.Get(testutil.BoneHair1), 0, 10); got != tt.want {
+				t.Errorf("isCurveSegmentIntact() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"github.com/miu200521358/bone_baker/pkg/domain/entity"
+	"github.com/miu200521358/mlib_go/pkg/config/mi18n"
+	"github.com/miu200521358/mlib_go/pkg/config/mlog"
 	"github.com/miu200521358/mlib_go/pkg/domain/mmath"
 	"github.com/miu200521358/mlib_go/pkg/domain/pmx"
 	"github.com/miu200521358/mlib_go/pkg/domain/vmd"
@@ -108,73 +110,218 @@ func (u *PhysicsUsecase) ApplyPhysicsModelMotion(
 			})
 		}
 
-		// 台形の線形補間で変形させる
-		for _, f := range []float32{record.MaxStartFrame, record.MaxEndFrame} {
-			// 最初と最後に最大キーを入れる
+		// 台形の斜辺は両端のキーだけを入れ、斜辺の終わりのキーに補間方法の補間曲線を設定する
+		curve := rampCurve(record.Easing)
 
-			// 前フレームから継続して物理演算を行う
-			physicsWorldMotion.AppendPhysicsResetFrame(vmd.NewPhysicsResetFrameByValue(f, vmd.PHYSICS_RESET_TYPE_CONTINUE_FRAME))
+		// 台形の上辺（最大値）を入れる（斜辺が無い場合は補間曲線を設定しない）
+		maxStartCurve := curve
+		if record.MaxStartFrame <= record.StartFrame {
+			maxStartCurve = nil
+		}
+		u.appendRatioModelFrames(physicsWorldMotion, physicsModelMotion, record, model, record.MaxStartFrame, 1, maxStartCurve)
+		u.appendRatioModelFrames(physicsWorldMotion, physicsModelMotion, record, model, record.MaxEndFrame, 1, nil)
 
-			// 剛体
-			model.RigidBodies.ForEach(func(rigidIndex int, rb *pmx.RigidBody) bool {
-				rigidBodyItem := record.Tree.AtByRigidBodyIndex(rb.Index())
+		// 斜辺の終わり（終了フレーム）の初期化キーに補間曲線を設定し直す
+		if curve != nil && record.EndFrame > record.MaxEndFrame {
+			u.appendRatioModelFrames(physicsWorldMotion, physicsModelMotion, record, model, record.EndFrame, 0, curve)
+		}
 
-				if rigidBodyItem == nil || !rigidBodyItem.Modified {
-					return true
-				}
+		// ステップは補間曲線で表せないため、斜辺の終わりの直前まで前の値を保つキーを入れる
+		if record.Easing != nil && record.Easing.Type == entity.EasingTypeStep {
+			if record.MaxStartFrame-1 > record.StartFrame {
+				u.appendRatioModelFrames(physicsWorldMotion, physicsModelMotion, record, model, record.MaxStartFrame-1, 0, nil)
+			}
+			if record.EndFrame-1 > record.MaxEndFrame {
+				u.appendRatioModelFrames(physicsWorldMotion, physicsModelMotion, record, model, record.EndFrame-1, 1, nil)
+			}
+		}
 
-				physicsModelMotion.AppendRigidBodyFrame(rb.Name(),
-					vmd.NewRigidBodyFrameByValues(
-						f,
-						rb.Position.Added(rigidBodyItem.Position),
-						rb.Size.Muled(rigidBodyItem.SizeRatio),
-						rb.RigidBodyParam.Mass*rigidBodyItem.MassRatio,
-					))
+		// 最初フレームの前には物理リセットしない（次キーフレを呼んでしまうので）
+		if record.StartFrame > 0 {
+			physicsWorldMotion.AppendPhysicsResetFrame(vmd.NewPhysicsResetFrameByValue(record.StartFrame-1, vmd.PHYSICS_RESET_TYPE_NONE))
+		}
+		// 最後のフレームの後に物理更新停止する
+		physicsWorldMotion.AppendPhysicsResetFrame(vmd.NewPhysicsResetFrameByValue(record.EndFrame+1, vmd.PHYSICS_RESET_TYPE_NONE))
+	}
+}
 
-				return true
-			})
+// rampCurve 台形の斜辺の終わりのキーに設定する補間曲線（線形・ステップの場合は nil）
+func rampCurve(easing *entity.Easing) *mmath.Curve {
+	if easing.IsLinear() || easing.Type == entity.EasingTypeStep {
+		return nil
+	}
+	return newCurve(easing)
+}
 
-			// ジョイント
-			model.Joints.ForEach(func(jointIndex int, joint *pmx.Joint) bool {
-				rigidBodyItemA := record.Tree.AtByRigidBodyIndex(joint.RigidBodyIndexA)
-				rigidBodyItemB := record.Tree.AtByRigidBodyIndex(joint.RigidBodyIndexB)
+// appendRatioModelFrames 変更前(0)から最大値(1)までの割合で、剛体・ジョイントのキーを入れる
+//   - curve は前のキーからの補間曲線（nil の場合は線形補間）
+func (u *PhysicsUsecase) appendRatioModelFrames(
+	physicsWorldMotion, physicsModelMotion *vmd.VmdMotion,
+	record *entity.RigidBodyRecord,
+	model *pmx.PmxModel,
+	f float32,
+	ratio float64,
+	curve *mmath.Curve,
+) {
+	// 前フレームから継続して物理演算を行う
+	physicsWorldMotion.AppendPhysicsResetFrame(vmd.NewPhysicsResetFrameByValue(f, vmd.PHYSICS_RESET_TYPE_CONTINUE_FRAME))
 
-				if rigidBodyItemA == nil || rigidBodyItemB == nil {
-					// ジョイントが繋がっている剛体のいずれかが未設定の場合はスキップ
-					return true
-				}
+	// 剛体
+	model.RigidBodies.ForEach(func(rigidIndex int, rb *pmx.RigidBody) bool {
+		rigidBodyItem := record.Tree.AtByRigidBodyIndex(rb.Index())
 
-				if !rigidBodyItemA.Modified && !rigidBodyItemB.Modified {
-					// 両方の剛体が未変更の場合はスキップ
-					return true
-				}
+		if rigidBodyItem == nil || !rigidBodyItem.Modified {
+			return true
+		}
 
-				// 両剛体の平均倍率を計算
-				avgStiffnessRatio := mmath.Mean([]float64{rigidBodyItemA.StiffnessRatio, rigidBodyItemB.StiffnessRatio})
-				avgTensionRatio := mmath.Mean([]float64{rigidBodyItemA.TensionRatio, rigidBodyItemB.TensionRatio})
+		position, size, mass := ratioRigidBodyParams(rb, rigidBodyItem, ratio)
+		rbf := vmd.NewRigidBodyFrameByValues(f, position, size, mass)
+		if curve != nil {
+			rbf.Curve = curve
+		}
+		physicsModelMotion.AppendRigidBodyFrame(rb.Name(), rbf)
 
-				physicsModelMotion.AppendJointFrame(joint.Name(),
-					vmd.NewJointFrameByValues(
-						f,
-						joint.JointParam.TranslationLimitMin.Copy(),
-						joint.JointParam.TranslationLimitMax.Copy(),
-						joint.JointParam.RotationLimitMin.DivedScalar(avgStiffnessRatio),
-						joint.JointParam.RotationLimitMax.DivedScalar(avgStiffnessRatio),
-						joint.JointParam.SpringConstantTranslation.MuledScalar(avgStiffnessRatio),
-						joint.JointParam.SpringConstantRotation.MuledScalar(avgTensionRatio),
-					))
+		return true
+	})
 
-				return true
-			})
+	// ジョイント
+	model.Joints.ForEach(func(jointIndex int, joint *pmx.Joint) bool {
+		rigidBodyItemA := record.Tree.AtByRigidBodyIndex(joint.RigidBodyIndexA)
+		rigidBodyItemB := record.Tree.AtByRigidBodyIndex(joint.RigidBodyIndexB)
+
+		if !isModifiedJoint(rigidBodyItemA, rigidBodyItemB) {
+			return true
 		}
 
-		// 最初フレームの前には物理リセットしない（次キーフレを呼んでしまうので）
-		if record.StartFrame > 0 {
-			physicsWorldMotion.AppendPhysicsResetFrame(vmd.NewPhysicsResetFrameByValue(record.StartFrame-1, vmd.PHYSICS_RESET_TYPE_NONE))
+		rotationLimitMin, rotationLimitMax, springConstantTranslation, springConstantRotation :=
+			ratioJointParams(joint, rigidBodyItemA, rigidBodyItemB, ratio)
+
+		jf := vmd.NewJointFrameByValues(
+			f,
+			joint.JointParam.TranslationLimitMin.Copy(),
+			joint.JointParam.TranslationLimitMax.Copy(),
+			rotationLimitMin,
+			rotationLimitMax,
+			springConstantTranslation,
+			springConstantRotation,
+		)
+		if curve != nil {
+			jf.Curve = curve
 		}
-		// 最後のフレームの後に物理更新停止する
-		physicsWorldMotion.AppendPhysicsResetFrame(vmd.NewPhysicsResetFrameByValue(record.EndFrame+1, vmd.PHYSICS_RESET_TYPE_NONE))
+		physicsModelMotion.AppendJointFrame(joint.Name(), jf)
+
+		return true
+	})
+}
+
+// ratioRigidBodyParams 剛体の位置・大きさ・質量を、変更前(0)から最大値(1)までの割合で求める
+func ratioRigidBodyParams(
+	rb *pmx.RigidBody, rigidBodyItem *entity.RigidBodyItem, ratio float64,
+) (position, size *mmath.MVec3, mass float64) {
+	sizeRatio := &mmath.MVec3{
+		X: lerpRatio(rigidBodyItem.SizeRatio.X, ratio),
+		Y: lerpRatio(rigidBodyItem.SizeRatio.Y, ratio),
+		Z: lerpRatio(rigidBodyItem.SizeRatio.Z, ratio),
 	}
+
+	return rb.Position.Added(rigidBodyItem.Position.MuledScalar(ratio)),
+		rb.Size.Muled(sizeRatio),
+		rb.RigidBodyParam.Mass * lerpRatio(rigidBodyItem.MassRatio, ratio)
+}
+
+// isModifiedJoint ジョイントが繋がっている両剛体が設定済みで、いずれかが変更されているか
+func isModifiedJoint(rigidBodyItemA, rigidBodyItemB *entity.RigidBodyItem) bool {
+	if rigidBodyItemA == nil || rigidBodyItemB == nil {
+		// ジョイントが繋がっている剛体のいずれかが未設定の場合はスキップ
+		return false
+	}
+
+	// 両方の剛体が未変更の場合はスキップ
+	return rigidBodyItemA.Modified || rigidBodyItemB.Modified
+}
+
+// ratioJointParams ジョイントの回転制限・ばね定数を、両剛体の平均倍率の割合で求める
+//   - 回転制限は硬さで割り、移動ばねは硬さ・回転ばねは張りを掛ける
+func ratioJointParams(
+	joint *pmx.Joint, rigidBodyItemA, rigidBodyItemB *entity.RigidBodyItem, ratio float64,
+) (rotationLimitMin, rotationLimitMax, springConstantTranslation, springConstantRotation *mmath.MVec3) {
+	// 両剛体の平均倍率を計算
+	avgStiffnessRatio := lerpRatio(mmath.Mean([]float64{rigidBodyItemA.StiffnessRatio, rigidBodyItemB.StiffnessRatio}), ratio)
+	avgTensionRatio := lerpRatio(mmath.Mean([]float64{rigidBodyItemA.TensionRatio, rigidBodyItemB.TensionRatio}), ratio)
+
+	return joint.JointParam.RotationLimitMin.DivedScalar(avgStiffnessRatio),
+		joint.JointParam.RotationLimitMax.DivedScalar(avgStiffnessRatio),
+		joint.JointParam.SpringConstantTranslation.MuledScalar(avgStiffnessRatio),
+		joint.JointParam.SpringConstantRotation.MuledScalar(avgTensionRatio)
+}
+
+// applyOutputPhysics 書き込み方法に従って、モデル物理設定の値をモデルの剛体・ジョイントに直接書き込む
+//   - 設定指定の場合は、指定した設定の最大値を書き込む
+//   - フレーム指定の場合は、剛体・ジョイント毎に、そのフレームを含んで変更している最後の設定の値を書き込む（後の設定のキーが優先されるため）
+func applyOutputPhysics(model *pmx.PmxModel, records []*entity.RigidBodyRecord, outputPhysics *entity.OutputPhysics) {
+	if model == nil || outputPhysics == nil {
+		return
+	}
+
+	switch outputPhysics.Type {
+	case entity.OutputPhysicsTypeRecord:
+		if outputPhysics.RecordIndex < 0 || outputPhysics.RecordIndex >= len(records) {
+			mlog.W(mi18n.T("書き込みモデル物理設定なし", map[string]any{"No": outputPhysics.RecordIndex + 1}))
+			return
+		}
+		applyRigidBodyRecordsToModel(model, records[outputPhysics.RecordIndex:outputPhysics.RecordIndex+1],
+			func(record *entity.RigidBodyRecord) float64 { return 1 })
+	case entity.OutputPhysicsTypeFrame:
+		applyRigidBodyRecordsToModel(model, records,
+			func(record *entity.RigidBodyRecord) float64 { return record.RatioAt(outputPhysics.Frame) })
+	}
+}
+
+// applyRigidBodyRecordsToModel モデル物理設定を割合に応じてモデルの剛体・ジョイントに書き込む
+//   - 剛体・ジョイント毎に、割合が正で、その剛体・ジョイントを変更している最後の設定を使う
+func applyRigidBodyRecordsToModel(
+	model *pmx.PmxModel, records []*entity.RigidBodyRecord, recordRatio func(record *entity.RigidBodyRecord) float64,
+) {
+	// ジョイントは剛体の倍率のみを参照するため、剛体より先に書き込んでも結果は変わらない
+	model.Joints.ForEach(func(jointIndex int, joint *pmx.Joint) bool {
+		for i := len(records) - 1; i >= 0; i-- {
+			ratio := recordRatio(records[i])
+			rigidBodyItemA := records[i].Tree.AtByRigidBodyIndex(joint.RigidBodyIndexA)
+			rigidBodyItemB := records[i].Tree.AtByRigidBodyIndex(joint.RigidBodyIndexB)
+
+			if ratio <= 0 || !isModifiedJoint(rigidBodyItemA, rigidBodyItemB) {
+				continue
+			}
+
+			joint.JointParam.RotationLimitMin, joint.JointParam.RotationLimitMax,
+				joint.JointParam.SpringConstantTranslation, joint.JointParam.SpringConstantRotation =
+				ratioJointParams(joint, rigidBodyItemA, rigidBodyItemB, ratio)
+			break
+		}
+
+		return true
+	})
+
+	model.RigidBodies.ForEach(func(rigidIndex int, rb *pmx.RigidBody) bool {
+		for i := len(records) - 1; i >= 0; i-- {
+			ratio := recordRatio(records[i])
+			rigidBodyItem := records[i].Tree.AtByRigidBodyIndex(rb.Index())
+
+			if ratio <= 0 || rigidBodyItem == nil || !rigidBodyItem.Modified {
+				continue
+			}
+
+			rb.Position, rb.Size, rb.RigidBodyParam.Mass = ratioRigidBodyParams(rb, rigidBodyItem, ratio)
+			break
+		}
+
+		return true
+	})
+}
+
+// lerpRatio 倍率 1 から指定倍率までを割合で補間する
+func lerpRatio(targetRatio, ratio float64) float64 {
+	return 1 + (targetRatio-1)*ratio
 }
 
 // ApplyWindMotion 風設定をVMDモーションに適用する
@@ -209,3 +356,36 @@ func (u *PhysicsUsecase) ApplyWindMotion(
 		windMotion.AppendPhysicsResetFrame(vmd.NewPhysicsResetFrameByValue(record.EndFrame+1, vmd.PHYSICS_RESET_TYPE_NONE))
 	}
 }
+
+// ApplyBakeSetWindMotion セット個別の風設定を、そのセットのモデル物理モーションに適用する
+// 全セット共通の風は ApplyWindMotion でワールド用の風モーションに適用する
+func (u *PhysicsUsecase) ApplyBakeSetWindMotion(
+	physicsModelMotion *vmd.VmdMotion,
+	bakeSet *entity.BakeSet,
+	globalRecords []*entity.WindRecord,
+) {
+	if bakeSet.WindScope != entity.WindScopeSet {
+		return
+	}
+
+	u.ApplyModelWindMotion(physicsModelMotion, bakeSet.WindRecords, globalRecords)
+}
+
+// ApplyModelWindMotion モデル個別の風設定を、モデル物理モーションに適用する
+//   - 全セット共通の風の区間は無風のキーを入れて、ワールドの風がこのモデルに掛からないようにする
+//   - 個別の風の区間は、無風のキーより後に入れて上書きする
+func (u *PhysicsUsecase) ApplyModelWindMotion(
+	physicsModelMotion *vmd.VmdMotion,
+	records []*entity.WindRecord,
+	globalRecords []*entity.WindRecord,
+) {
+	modelRecords := make([]*entity.WindRecord, 0, len(globalRecords)+len(records))
+	for _, globalRecord := range globalRecords {
+		calmRecord := entity.NewWindRecord(globalRecord.StartFrame, globalRecord.EndFrame)
+		calmRecord.WindConfig.Enabled = false
+		modelRecords = append(modelRecords, calmRecord)
+	}
+	modelRecords = append(modelRecords, records...)
+
+	u.ApplyWindMotion(physicsModelMotion, modelRecords)
+}
@@ -0,0 +1,263 @@
+package usecase
+
+import (
+	"math"
+	"testing"
+
+	"github.com/miu200521358/bone_baker/pkg/domain/entity"
+	"github.com/miu200521358/bone_baker/pkg/testutil"
+	"github.com/miu200521358/mlib_go/pkg/domain/pmx"
+	"github.com/miu200521358/mlib_go/pkg/domain/vmd"
+)
+
+func TestPhysicsUsecase_ApplyPhysicsModelMotion(t *testing.T) {
+	tests := []struct {
+		name          string
+		startFrame    float32
+		maxStartFrame float32
+		maxEndFrame   float32
+		endFrame      float32
+		easing        *entity.Easing
+		massRatio     float64 // 髪1の質量倍率（1の場合は未変更）
+		wantFrames    []float32
+		notFrames     []float32
+		curveFrames   []float32 // 補間曲線を設定するキー
+		wantMass      map[float32]float64
+		wantJoint     bool
+	}{
+		{
+			name:          "開始フレーム0の台形",
+			startFrame:    0,
+			maxStartFrame: 5,
+			maxEndFrame:   10,
+			endFrame:      15,
+			easing:        entity.NewEasing(entity.EasingTypeLinear),
+			massRatio:     2,
+			wantFrames:    []float32{0, 5, 10, 15, 16},
+			notFrames:     []float32{3, 12},
+			wantMass:      map[float32]float64{0: 1, 5: 2, 10: 2, 15: 1, 16: 1},
+			wantJoint:     true,
+		},
+		{
+			name:          "開始フレームの前に初期化キー",
+			startFrame:    10,
+			maxStartFrame: 12,
+			maxEndFrame:   20,
+			endFrame:      22,
+			easing:        entity.NewEasing(entity.EasingTypeLinear),
+			massRatio:     3,
+			wantFrames:    []float32{9, 10, 12, 20, 22, 23},
+			wantMass:      map[float32]float64{9: 1, 10: 1, 12: 3, 20: 3, 22: 1},
+			wantJoint:     true,
+		},
+		{
+			name:          "イージングは斜辺の終わりのキーに補間曲線を設定する",
+			startFrame:    0,
+			maxStartFrame: 5,
+			maxEndFrame:   10,
+			endFrame:      15,
+			easing:        entity.NewEasing(entity.EasingTypeEaseInOut),
+			massRatio:     2,
+			wantFrames:    []float32{0, 5, 10, 15, 16},
+			notFrames:     []float32{1, 2, 3, 4, 11, 12, 13, 14},
+			curveFrames:   []float32{5, 15},
+			wantMass:      map[float32]float64{0: 1, 5: 2, 10: 2, 15: 1},
+			wantJoint:     true,
+		},
+		{
+			name:          "ステップは斜辺の終わりの直前まで前の値を保つ",
+			startFrame:    0,
+			maxStartFrame: 4,
+			maxEndFrame:   8,
+			endFrame:      12,
+			easing:        entity.NewEasing(entity.EasingTypeStep),
+			massRatio:     2,
+			wantFrames:    []float32{3, 11},
+			notFrames:     []float32{1, 2, 9, 10},
+			wantMass:      map[float32]float64{3: 1, 4: 2, 9: 2, 11: 2, 12: 1},
+			wantJoint:     true,
+		},
+		{
+			name:          "未変更の剛体はキーを入れない",
+			startFrame:    0,
+			maxStartFrame: 5,
+			maxEndFrame:   10,
+			endFrame:      15,
+			easing:        entity.NewEasing(entity.EasingTypeLinear),
+			massRatio:     1,
+			wantJoint:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := testutil.NewModel()
+
+			record := entity.NewRigidBodyRecord(tt.startFrame, tt.endFrame, model)
+			record.MaxStartFrame = tt.maxStartFrame
+			record.MaxEndFrame = tt.maxEndFrame
+			record.Easing = tt.easing
+
+			if tt.massRatio != 1 {
+				rigidBody, _ := model.RigidBodies.GetByName(testutil.RigidBodyHair1)
+				item := record.Tree.AtByRigidBodyIndex(rigidBody.Index())
+				if item == nil {
+					t.Fatalf("rigid body item %s not found", testutil.RigidBodyHair1)
+				}
+				item.MassRatio = tt.massRatio
+				item.Modified = true
+			}
+
+			physicsWorldMotion := vmd.NewVmdMotion("")
+			physicsModelMotion := vmd.NewVmdMotion("")
+
+			uc := NewPhysicsUsecase()
+			uc.ApplyPhysicsModelMotion(physicsWorldMotion, physicsModelMotion, []*entity.RigidBodyRecord{record}, model)
+
+			if tt.massRatio == 1 {
+				if physicsModelMotion.RigidBodyFrames.Contains(testutil.RigidBodyHair1) {
+					t.Errorf("unmodified rigid body %s has frames", testutil.RigidBodyHair1)
+				}
+			}
+
+			// 未変更の剛体（髪2）にはキーを入れない
+			if physicsModelMotion.RigidBodyFrames.Contains(testutil.RigidBodyHair2) {
+				t.Errorf("unmodified rigid body %s has frames", testutil.RigidBodyHair2)
+			}
+
+			if got := physicsModelMotion.JointFrames.Contains(testutil.JointHair); got != tt.wantJoint {
+				t.Errorf("joint frames exist = %v, want %v", got, tt.wantJoint)
+			}
+
+			if tt.massRatio == 1 {
+				return
+			}
+
+			frames := physicsModelMotion.RigidBodyFrames.Get(testutil.RigidBodyHair1)
+			for _, f := range tt.wantFrames {
+				if !frames.Contains(f) {
+					t.Errorf("frame %v not found", f)
+				}
+			}
+			for _, f := range tt.notFrames {
+				if frames.Contains(f) {
+					t.Errorf("unexpected frame %v", f)
+				}
+			}
+			for _, f := range tt.curveFrames {
+				if frames.Get(f).Curve == nil {
+					t.Errorf("curve at %v not set", f)
+				}
+			}
+			for f, wantMass := range tt.wantMass {
+				if got := frames.Get(f).Mass; math.Abs(got-wantMass) > 1e-6 {
+					t.Errorf("mass at %v = %v, want %v", f, got, wantMass)
+				}
+			}
+		})
+	}
+}
+
+func TestPhysicsUsecase_ApplyPhysicsModelMotion_EmptyRecords(t *testing.T) {
+	model := testutil.NewModel()
+	physicsWorldMotion := vmd.NewVmdMotion("")
+	physicsModelMotion := vmd.NewVmdMotion("")
+
+	NewPhysicsUsecase().ApplyPhysicsModelMotion(physicsWorldMotion, physicsModelMotion, nil, model)
+
+	model.RigidBodies.ForEach(func(index int, rigidBody *pmx.RigidBody) bool {
+		if physicsModelMotion.RigidBodyFrames.Contains(rigidBody.Name()) {
+			t.Errorf("rigid body %s has frames", rigidBody.Name())
+		}
+		return true
+	})
+}
+
+func TestApplyOutputPhysics(t *testing.T) {
+	tests := []struct {
+		name          string
+		outputPhysics *entity.OutputPhysics
+		wantMass      float64
+	}{
+		{name: "書き込みなし", outputPhysics: entity.NewOutputPhysics(), wantMass: 1},
+		{name: "設定の最大値", outputPhysics: &entity.OutputPhysics{Type: entity.OutputPhysicsTypeRecord, RecordIndex: 0}, wantMass: 3},
+		{name: "変化区間の中間フレーム", outputPhysics: &entity.OutputPhysics{Type: entity.OutputPhysicsTypeFrame, Frame: 5}, wantMass: 2},
+		{name: "区間外のフレーム", outputPhysics: &entity.OutputPhysics{Type: entity.OutputPhysicsTypeFrame, Frame: 30}, wantMass: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := testutil.NewModel()
+			rigidBody, _ := model.RigidBodies.GetByName(testutil.RigidBodyHair1)
+
+			record := entity.NewRigidBodyRecord(0, 20, model)
+			record.MaxStartFrame = 10
+			record.MaxEndFrame = 10
+			item := record.Tree.AtByRigidBodyIndex(rigidBody.Index())
+			item.MassRatio = 3
+			item.Modified = true
+
+			applyOutputPhysics(model, []*entity.RigidBodyRecord{record}, tt.outputPhysics)
+
+			if got := rigidBody.RigidBodyParam.Mass; math.Abs(got-tt.wantMass) > 1e-6 {
+				t.Errorf("mass = %v, want %v", got, tt.wantMass)
+			}
+		})
+	}
+}
+
+func TestApplyOutputPhysics_OverlappingRecords(t *testing.T) {
+	// 重なる2つの設定が、それぞれ別の剛体を変更している
+	model := testutil.NewModel()
+	rigidBody1, _ := model.RigidBodies.GetByName(testutil.RigidBodyHair1)
+	rigidBody2, _ := model.RigidBodies.GetByName(testutil.RigidBodyHair2)
+
+	newRecord := func(rigidBody *pmx.RigidBody, massRatio float64) *entity.RigidBodyRecord {
+		record := entity.NewRigidBodyRecord(0, 20, model)
+		record.MaxStartFrame = 10
+		record.MaxEndFrame = 10
+		item := record.Tree.AtByRigidBodyIndex(rigidBody.Index())
+		item.MassRatio = massRatio
+		item.Modified = true
+		return record
+	}
+	records := []*entity.RigidBodyRecord{newRecord(rigidBody1, 3), newRecord(rigidBody2, 2)}
+
+	applyOutputPhysics(model, records, &entity.OutputPhysics{Type: entity.OutputPhysicsTypeFrame, Frame: 10})
+
+	if got := rigidBody1.RigidBodyParam.Mass; math.Abs(got-3) > 1e-6 {
+		t.Errorf("mass1 = %v, want 3", got)
+	}
+	if got := rigidBody2.RigidBodyParam.Mass; math.Abs(got-2) > 1e-6 {
+		t.Errorf("mass2 = %v, want 2", got)
+	}
+}
+
+func TestPhysicsUsecase_ApplyModelWindMotion(t *testing.T) {
+	globalRecord := entity.NewWindRecord(0, 10)
+	globalRecord.WindConfig.Enabled = true
+	setRecord := entity.NewWindRecord(5, 8)
+	setRecord.WindConfig.Enabled = true
+
+	physicsModelMotion := vmd.NewVmdMotion("")
+	NewPhysicsUsecase().ApplyModelWindMotion(
+		physicsModelMotion, []*entity.WindRecord{setRecord}, []*entity.WindRecord{globalRecord})
+
+	tests := []struct {
+		name  string
+		frame float32
+		want  bool
+	}{
+		{name: "全体共通の風のみの区間は無風", frame: 2, want: false},
+		{name: "セット個別の風の区間", frame: 6, want: true},
+		{name: "セット個別の風の後も無風", frame: 10, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := physicsModelMotion.WindEnabledFrames.Get(tt.frame).Enabled; got != tt.want {
+				t.Errorf("enabled = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
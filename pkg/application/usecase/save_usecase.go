@@ -3,18 +3,103 @@ package usecase
 import (
 	"github.com/miu200521358/bone_baker/pkg/domain/entity"
 	pRepository "github.com/miu200521358/bone_baker/pkg/infrastructure/repository"
+	"github.com/miu200521358/mlib_go/pkg/config/mi18n"
+	"github.com/miu200521358/mlib_go/pkg/config/mlog"
+	"github.com/miu200521358/mlib_go/pkg/domain/pmx"
+	"github.com/miu200521358/mlib_go/pkg/infrastructure/repository"
 )
 
 type SaveUsecase struct {
-	fileRepo *pRepository.FileRepository
+	fileRepo   *pRepository.FileRepository
+	reportRepo *pRepository.ReportRepository
 }
 
-func NewSaveUsecase(fileRepo *pRepository.FileRepository) *SaveUsecase {
+func NewSaveUsecase(fileRepo *pRepository.FileRepository, reportRepo *pRepository.ReportRepository) *SaveUsecase {
 	return &SaveUsecase{
-		fileRepo: fileRepo,
+		fileRepo:   fileRepo,
+		reportRepo: reportRepo,
 	}
 }
 
-func (uc *SaveUsecase) SaveFile(bakeSets []*entity.BakeSet, physicsRecords []*entity.PhysicsRecord, path string) error {
-	return uc.fileRepo.Save(bakeSets, physicsRecords, path)
+func (uc *SaveUsecase) SaveFile(
+	bakeSets []*entity.BakeSet,
+	physicsRecords []*entity.PhysicsRecord,
+	windRecords []*entity.WindRecord,
+	path string,
+) error {
+	return uc.fileRepo.Save(bakeSets, physicsRecords, windRecords, path)
+}
+
+// SaveBakeReport 焼き込みレポートを出力モーションの隣に保存
+func (uc *SaveUsecase) SaveBakeReport(report *entity.BakeReport, outputMotionPath string) error {
+	return uc.reportRepo.Save(report, outputMotionPath)
+}
+
+// SaveOutputModel 出力モデルを保存
+//   - 通常は、システム用ボーン・衝突用剛体を含む物理確認用の元モデルを保存する
+//   - 未加工モデルで保存する場合は、元のPMXを読み直して、焼き込みモーションに必要な改名のみ反映して保存する
+//   - モデル物理設定の書き込みを指定した場合は、剛体・ジョイントにその値を書き込んで保存する
+func (uc *SaveUsecase) SaveOutputModel(bakeSet *entity.BakeSet, path string) error {
+	rep := repository.NewPmxRepository(true)
+
+	if !bakeSet.OutputCleanModel {
+		if bakeSet.OutputPhysics == nil || bakeSet.OutputPhysics.Type == entity.OutputPhysicsTypeNone {
+			return rep.Save(path, bakeSet.OriginalModel, false)
+		}
+
+		// 表示中の元モデルは変更しないよう、複製に書き込む
+		model, err := bakeSet.OriginalModel.Copy()
+		if err != nil {
+			mlog.E(mi18n.T("モデル保存失敗"), err, "")
+			return err
+		}
+		applyOutputPhysics(model, bakeSet.RigidBodyRecords, bakeSet.OutputPhysics)
+
+		return rep.Save(path, model, false)
+	}
+
+	data, err := rep.Load(bakeSet.OriginalModelPath)
+	if err != nil {
+		mlog.E(mi18n.T("未加工モデル読み込み失敗"), err, "")
+		return err
+	}
+	model := data.(*pmx.PmxModel)
+
+	if bakeSet.OutputBoneNameType == entity.OutputBoneNameTypeBake {
+		// 焼き込みモーションは改名後のボーン名でキーを持つため、物理ボーンのみ改名する
+		renameToBakeBoneNames(model, bakeSet)
+	}
+
+	// 衝突用剛体は元モデルの末尾に追加しているため、元の剛体のINDEXはそのまま使える
+	applyOutputPhysics(model, bakeSet.RigidBodyRecords, bakeSet.OutputPhysics)
+
+	return rep.Save(path, model, false)
+}
+
+// renameToBakeBoneNames 未加工モデルの物理ボーンを、焼き込み用の名前に改名する
+//   - 元の名前が重複する物理ボーンは、モデル内の並び順で焼き込み用の名前と対応付ける
+func renameToBakeBoneNames(model *pmx.PmxModel, bakeSet *entity.BakeSet) {
+	if bakeSet.OriginalModel == nil {
+		return
+	}
+
+	// 元の名前 → 焼き込み用の名前（焼き込みモデル内の並び順）
+	bakeBoneNames := make(map[string][]string)
+	bakeSet.OriginalModel.Bones.ForEach(func(boneIndex int, bone *pmx.Bone) bool {
+		if originalBoneName, ok := bakeSet.BoneNameMap[bone.Name()]; ok {
+			bakeBoneNames[originalBoneName] = append(bakeBoneNames[originalBoneName], bone.Name())
+		}
+		return true
+	})
+
+	model.Bones.ForEach(func(boneIndex int, bone *pmx.Bone) bool {
+		names := bakeBoneNames[bone.Name()]
+		if len(names) == 0 || !bone.HasDynamicPhysics() {
+			return true
+		}
+		bakeBoneNames[bone.Name()] = names[1:]
+		bone.SetName(names[0])
+		return true
+	})
+	model.Bones.UpdateNameIndexes()
 }
@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/miu200521358/bone_baker/pkg/domain/entity"
+	"github.com/miu200521358/mlib_go/pkg/config/merr"
+	"github.com/miu200521358/mlib_go/pkg/config/mi18n"
+	"github.com/miu200521358/mlib_go/pkg/config/mlog"
+	"github.com/miu200521358/mlib_go/pkg/domain/delta"
+	"github.com/miu200521358/mlib_go/pkg/domain/mmath"
+	"github.com/miu200521358/mlib_go/pkg/domain/physics"
+	"github.com/miu200521358/mlib_go/pkg/domain/pmx"
+	"github.com/miu200521358/mlib_go/pkg/domain/vmd"
+	"github.com/miu200521358/mlib_go/pkg/infrastructure/mbt"
+	"github.com/miu200521358/mlib_go/pkg/usecase/deform"
+)
+
+// MMDの1フレームあたりの経過時間[s]
+const frameTimeStep = float32(1.0 / 30.0)
+
+// SimulationUsecase ビューワーを介さずに物理演算を行い、焼き込み結果モーションを生成する
+type SimulationUsecase struct {
+}
+
+func NewSimulationUsecase() *SimulationUsecase {
+	return &SimulationUsecase{}
+}
+
+// Simulate 元モデル・元モーションの物理演算を1フレームずつ進め、結果を BakeSet.OutputMotion に設定する
+// 物理ワールド・モデル物理・風の各モーションは PhysicsUsecase で生成したものを渡す
+// セット個別の風はモデル物理モーション、全セット共通の風は風モーションから読み込む
+// 各モーションの物理リセットは、ビューワーでの再生と同じくフレーム毎に反映する
+func (uc *SimulationUsecase) Simulate(
+	bakeSet *entity.BakeSet,
+	physicsWorldMotion, physicsModelMotion, windMotion *vmd.VmdMotion,
+	incrementCompletedCount func(),
+	isTerminate func() bool,
+) error {
+	model := bakeSet.OriginalModel
+	motion := bakeSet.OriginalMotion
+	if model == nil || motion == nil {
+		return nil
+	}
+
+	worldRecord := uc.worldRecord(physicsWorldMotion, 0)
+
+	physicsWorld := mbt.NewMPhysics(worldRecord.gravity)
+	physicsWorld.AddModel(0, model)
+	defer physicsWorld.DeleteModel(0)
+
+	outputMotion := vmd.NewVmdMotion(motion.Path())
+	var vmdDeltas *delta.VmdDeltas
+
+	for f := float32(0); f <= motion.MaxFrame(); f++ {
+		if isTerminate() {
+			return merr.NewTerminateError("manual terminate")
+		}
+
+		// ワールド物理
+		record := uc.worldRecord(physicsWorldMotion, f)
+
+		// 各モーションの物理リセットに従って、ビューワーと同じくワールド・モデルをリセットする
+		switch uc.resetType(f, physicsWorldMotion, physicsModelMotion, windMotion) {
+		case vmd.PHYSICS_RESET_TYPE_START_FRAME, vmd.PHYSICS_RESET_TYPE_START_FIT_FRAME:
+			// 現フレームの姿勢から物理演算をやり直す
+			physicsWorld.DeleteModel(0)
+			physicsWorld.ResetWorld(record.gravity)
+			physicsWorld.AddModel(0, model)
+			vmdDeltas = nil
+		case vmd.PHYSICS_RESET_TYPE_CONTINUE_FRAME:
+			// 剛体の状態を引き継いだまま、ワールドを作り直す
+			physicsWorld.ResetWorld(record.gravity)
+		default:
+			// 重力が変わった場合はワールドを作り直す
+			if !record.gravity.NearEquals(worldRecord.gravity, 1e-6) {
+				physicsWorld.ResetWorld(record.gravity)
+			}
+		}
+		worldRecord = record
+
+		// 風
+		physicsWorld.SetWindConfig(uc.windConfig(f, physicsModelMotion, windMotion))
+
+		vmdDeltas = deform.DeformBeforePhysics(model, motion, vmdDeltas, f)
+
+		// モデル物理（剛体・ジョイントのパラメータ変更）
+		physicsWorld.UpdatePhysicsSelectively(0, model, deform.DeformPhysics(model, physicsModelMotion, f))
+
+		deform.DeformPhysicsByBone(physicsWorld, model, vmdDeltas, 0)
+		physicsWorld.StepSimulation(frameTimeStep, worldRecord.maxSubSteps, worldRecord.fixedTimeStep)
+		vmdDeltas = deform.DeformAfterPhysics(physicsWorld, model, vmdDeltas, 0)
+
+		model.Bones.ForEach(func(boneIndex int, bone *pmx.Bone) bool {
+			outputMotion.AppendBoneFrame(bone.Name(), uc.newDeltaBoneFrame(f, model, bone, vmdDeltas))
+			return true
+		})
+
+		incrementCompletedCount()
+
+		if int(f)%1000 == 0 {
+			mlog.I(fmt.Sprintf(mi18n.T("--- [%04d/%04d] 物理演算処理中 ..."), int(f), int(motion.MaxFrame())))
+		}
+	}
+
+	bakeSet.OutputMotion = outputMotion
+
+	return nil
+}
+
+// resetType 各モーションに登録された、指定フレームの物理リセット種別
+//   - 複数のモーションにキーがある場合は、最も強いリセットを採用する（未登録の場合はリセットなし）
+func (uc *SimulationUsecase) resetType(frame float32, motions ...*vmd.VmdMotion) vmd.PhysicsResetType {
+	resetType := vmd.PHYSICS_RESET_TYPE_NONE
+	for _, motion := range motions {
+		if motion == nil || !motion.PhysicsResetFrames.Contains(frame) {
+			continue
+		}
+		resetType = max(resetType, motion.PhysicsResetFrames.Get(frame).PhysicsResetType)
+	}
+	return resetType
+}
+
+// simulationWorldRecord 1フレーム分のワールド物理設定
+type simulationWorldRecord struct {
+	gravity       *mmath.MVec3
+	maxSubSteps   int
+	fixedTimeStep float32
+}
+
+// worldRecord 物理ワールドモーションから指定フレームの設定を取得する（未設定の場合は初期値）
+func (uc *SimulationUsecase) worldRecord(physicsWorldMotion *vmd.VmdMotion, frame float32) *simulationWorldRecord {
+	defaultRecord := entity.NewPhysicsRecord(frame, frame)
+	record := &simulationWorldRecord{
+		gravity:       &mmath.MVec3{X: 0, Y: defaultRecord.Gravity, Z: 0},
+		maxSubSteps:   defaultRecord.MaxSubSteps,
+		fixedTimeStep: float32(1.0 / defaultRecord.FixedTimeStep),
+	}
+
+	if physicsWorldMotion == nil {
+		return record
+	}
+
+	if physicsWorldMotion.GravityFrames.Len() > 0 {
+		record.gravity = physicsWorldMotion.GravityFrames.Get(frame).Gravity.Copy()
+	}
+	if physicsWorldMotion.MaxSubStepsFrames.Len() > 0 {
+		record.maxSubSteps = physicsWorldMotion.MaxSubStepsFrames.Get(frame).MaxSubSteps
+	}
+	if physicsWorldMotion.FixedTimeStepFrames.Len() > 0 {
+		record.fixedTimeStep = float32(1.0 / physicsWorldMotion.FixedTimeStepFrames.Get(frame).FixedTimeStep)
+	}
+
+	return record
+}
+
+// windConfig 指定フレームの風設定を取得する
+//   - モデル物理モーションに風（セット個別の風）がある場合は、ワールドの風より優先する
+//   - どちらにも風が未設定の場合は無風
+func (uc *SimulationUsecase) windConfig(frame float32, motions ...*vmd.VmdMotion) *physics.WindConfig {
+	for _, motion := range motions {
+		if motion == nil || motion.WindEnabledFrames.Len() == 0 {
+			continue
+		}
+
+		return &physics.WindConfig{
+			Enabled:          motion.WindEnabledFrames.Get(frame).Enabled,
+			Direction:        motion.WindDirectionFrames.Get(frame).Direction.Copy(),
+			Speed:            motion.WindSpeedFrames.Get(frame).Speed,
+			Randomness:       motion.WindRandomnessFrames.Get(frame).Randomness,
+			TurbulenceFreqHz: motion.WindTurbulenceFreqHzFrames.Get(frame).TurbulenceFreqHz,
+			DragCoeff:        motion.WindDragCoeffFrames.Get(frame).DragCoeff,
+			LiftCoeff:        motion.WindLiftCoeffFrames.Get(frame).LiftCoeff,
+		}
+	}
+
+	return &physics.WindConfig{Enabled: false, Direction: mmath.NewMVec3()}
+}
+
+// newDeltaBoneFrame 変形結果のグローバル行列から親ボーン基準のキーフレームを生成
+func (uc *SimulationUsecase) newDeltaBoneFrame(
+	frame float32, model *pmx.PmxModel, bone *pmx.Bone, vmdDeltas *delta.VmdDeltas,
+) *vmd.BoneFrame {
+	globalMatrix := vmdDeltas.Bones.Get(bone.Index()).FilledGlobalMatrix()
+	offset := bone.Position.Copy()
+
+	localMatrix := globalMatrix
+	if parent, err := model.Bones.Get(bone.ParentIndex); err == nil {
+		localMatrix = vmdDeltas.Bones.Get(parent.Index()).FilledGlobalMatrix().Inverted().Muled(globalMatrix)
+		offset = bone.Position.Subed(parent.Position)
+	}
+
+	bf := vmd.NewBoneFrame(frame)
+	bf.Position = localMatrix.Translation().Subed(offset)
+	bf.Rotation = localMatrix.Quaternion()
+
+	return bf
+}
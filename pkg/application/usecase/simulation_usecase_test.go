@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/miu200521358/bone_baker/pkg/domain/entity"
+	"github.com/miu200521358/bone_baker/pkg/testutil"
+	"github.com/miu200521358/mlib_go/pkg/domain/mmath"
+	"github.com/miu200521358/mlib_go/pkg/domain/vmd"
+)
+
+func TestSimulationUsecase_resetType(t *testing.T) {
+	// 10-20F のワールド物理設定と、15-30F の風設定
+	physicsWorldMotion := vmd.NewVmdMotion("")
+	NewPhysicsUsecase().ApplyPhysicsWorldMotion(physicsWorldMotion, []*entity.PhysicsRecord{entity.NewPhysicsRecord(10, 20)})
+
+	windMotion := vmd.NewVmdMotion("")
+	windMotion.AppendPhysicsResetFrame(vmd.NewPhysicsResetFrameByValue(15, vmd.PHYSICS_RESET_TYPE_START_FRAME))
+
+	tests := []struct {
+		name  string
+		frame float32
+		want  vmd.PhysicsResetType
+	}{
+		{name: "キーなし", frame: 5, want: vmd.PHYSICS_RESET_TYPE_NONE},
+		{name: "設定開始は継続", frame: 10, want: vmd.PHYSICS_RESET_TYPE_CONTINUE_FRAME},
+		{name: "設定途中はリセットなし", frame: 12, want: vmd.PHYSICS_RESET_TYPE_NONE},
+		{name: "別モーションの強いリセットを優先", frame: 15, want: vmd.PHYSICS_RESET_TYPE_START_FRAME},
+	}
+
+	uc := NewSimulationUsecase()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := uc.resetType(tt.frame, physicsWorldMotion, nil, windMotion); got != tt.want {
+				t.Errorf("resetType = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSimulationUsecase_windConfig(t *testing.T) {
+	// 全体共通は 0-10F に風速 5、セット個別は 5-10F に風速 20
+	globalRecord := entity.NewWindRecord(0, 10)
+	globalRecord.WindConfig.Speed = 5
+	setRecord := entity.NewWindRecord(5, 10)
+	setRecord.WindConfig.Speed = 20
+
+	windMotion := vmd.NewVmdMotion("")
+	NewPhysicsUsecase().ApplyWindMotion(windMotion, []*entity.WindRecord{globalRecord})
+
+	bakeSet := entity.NewBakeSet(0)
+	bakeSet.WindScope = entity.WindScopeSet
+	bakeSet.WindRecords = []*entity.WindRecord{setRecord}
+	physicsModelMotion := vmd.NewVmdMotion("")
+	NewPhysicsUsecase().ApplyBakeSetWindMotion(physicsModelMotion, bakeSet, []*entity.WindRecord{globalRecord})
+
+	tests := []struct {
+		name               string
+		physicsModelMotion *vmd.VmdMotion
+		frame              float32
+		wantEnabled        bool
+		wantSpeed          float32
+	}{
+		{name: "全体共通の風", physicsModelMotion: nil, frame: 7, wantEnabled: true, wantSpeed: 5},
+		{name: "セット個別の風を優先", physicsModelMotion: physicsModelMotion, frame: 7, wantEnabled: true, wantSpeed: 20},
+		{name: "セット個別の風の区間外は無風", physicsModelMotion: physicsModelMotion, frame: 2, wantEnabled: false},
+	}
+
+	uc := NewSimulationUsecase()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := uc.windConfig(tt.frame, tt.physicsModelMotion, windMotion)
+			if got.Enabled != tt.wantEnabled {
+				t.Errorf("Enabled = %v, want %v", got.Enabled, tt.wantEnabled)
+			}
+			if tt.wantEnabled && got.Speed != tt.wantSpeed {
+				t.Errorf("Speed = %v, want %v", got.Speed, tt.wantSpeed)
+			}
+		})
+	}
+}
+
+func TestSimulationUsecase_Simulate_SetWind(t *testing.T) {
+	// セット個別の風を設定した場合と無風の場合で、髪ボーンの物理結果を比較する
+	simulate := func(windScope entity.WindScope) *vmd.VmdMotion {
+		bakeSet := entity.NewBakeSet(0)
+		bakeSet.OriginalModel = testutil.NewModel()
+		bakeSet.OriginalMotion = testutil.NewMotion()
+		bakeSet.WindScope = windScope
+
+		record := entity.NewWindRecord(0, 10)
+		record.WindConfig.Direction = &mmath.MVec3{X: 1, Y: 0, Z: 0}
+		record.WindConfig.Speed = 50
+		bakeSet.WindRecords = []*entity.WindRecord{record}
+
+		physicsWorldMotion := vmd.NewVmdMotion("")
+		physicsModelMotion := vmd.NewVmdMotion("")
+		windMotion := vmd.NewVmdMotion("")
+
+		physicsUsecase := NewPhysicsUsecase()
+		physicsUsecase.ApplyPhysicsWorldMotion(physicsWorldMotion, []*entity.PhysicsRecord{})
+		physicsUsecase.ApplyWindMotion(windMotion, []*entity.WindRecord{})
+		physicsUsecase.ApplyBakeSetWindMotion(physicsModelMotion, bakeSet, []*entity.WindRecord{})
+
+		if err := NewSimulationUsecase().Simulate(
+			bakeSet, physicsWorldMotion, physicsModelMotion, windMotion, func() {}, func() bool { return false },
+		); err != nil {
+			t.Fatalf("Simulate error: %v", err)
+		}
+		return bakeSet.OutputMotion
+	}
+
+	calmMotion := simulate(entity.WindScopeGlobal)
+	windMotion := simulate(entity.WindScopeSet)
+
+	calmRotation := calmMotion.BoneFrames.Get(testutil.BoneHair1).Get(10).FilledRotation()
+	windRotation := windMotion.BoneFrames.Get(testutil.BoneHair1).Get(10).FilledRotation()
+	if windRotation.NearEquals(calmRotation, 1e-4) {
+		t.Errorf("%s rotation = %v, want moved by set wind (calm %v)", testutil.BoneHair1, windRotation, calmRotation)
+	}
+}
@@ -0,0 +1,329 @@
+package usecase
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/miu200521358/bone_baker/pkg/domain/entity"
+	"github.com/miu200521358/mlib_go/pkg/config/mi18n"
+	"github.com/miu200521358/mlib_go/pkg/config/mlog"
+	"github.com/miu200521358/mlib_go/pkg/domain/pmx"
+	"github.com/miu200521358/mlib_go/pkg/domain/vmd"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/unicode/norm"
+)
+
+type ValidationUsecase struct {
+}
+
+func NewValidationUsecase() *ValidationUsecase {
+	return &ValidationUsecase{}
+}
+
+// Validate 読み込み済みのモデルを焼き込み前に検査し、見つかった問題を返す
+//   - boneNameMap は読み込み時の物理ボーンの改名対応表（焼き込み用の名前 → 元の名前）
+func (uc *ValidationUsecase) Validate(model *pmx.PmxModel, boneNameMap map[string]string) []*entity.ValidationIssue {
+	issues := make([]*entity.ValidationIssue, 0)
+	if model == nil {
+		return issues
+	}
+
+	issues = append(issues, uc.validateRigidBodies(model)...)
+	issues = append(issues, uc.validateJoints(model)...)
+	issues = append(issues, uc.validateBones(model, boneNameMap)...)
+
+	return issues
+}
+
+// validateRigidBodies 剛体の質量・ボーンの紐付けを検査
+func (uc *ValidationUsecase) validateRigidBodies(model *pmx.PmxModel) []*entity.ValidationIssue {
+	issues := make([]*entity.ValidationIssue, 0)
+
+	model.RigidBodies.ForEach(func(rigidIndex int, rb *pmx.RigidBody) bool {
+		if rb.IsSystem {
+			// ボーンベイカーが追加した衝突用剛体は対象外
+			return true
+		}
+
+		if rb.RigidBodyParam.Mass <= 0 && rb.PhysicsType != pmx.PHYSICS_TYPE_STATIC {
+			// 物理演算する剛体の質量が0以下の場合、静的剛体として扱われてしまう
+			issues = append(issues, entity.NewValidationIssue(
+				entity.ValidationSeverityError, entity.ValidationCodeRigidBodyMass, rb.Name(),
+				map[string]any{"Mass": rb.RigidBodyParam.Mass}))
+		} else if rb.RigidBodyParam.Mass < 0 {
+			issues = append(issues, entity.NewValidationIssue(
+				entity.ValidationSeverityWarning, entity.ValidationCodeRigidBodyMass, rb.Name(),
+				map[string]any{"Mass": rb.RigidBodyParam.Mass}))
+		}
+
+		if rb.BoneIndex < 0 || rb.BoneIndex >= model.Bones.Length() {
+			issues = append(issues, entity.NewValidationIssue(
+				entity.ValidationSeverityWarning, entity.ValidationCodeRigidBodyNoBone, rb.Name(), nil))
+		}
+
+		return true
+	})
+
+	return issues
+}
+
+// validateJoints ジョイントの剛体の紐付けを検査
+func (uc *ValidationUsecase) validateJoints(model *pmx.PmxModel) []*entity.ValidationIssue {
+	issues := make([]*entity.ValidationIssue, 0)
+
+	model.Joints.ForEach(func(jointIndex int, joint *pmx.Joint) bool {
+		switch {
+		case joint.RigidBodyIndexA < 0 || joint.RigidBodyIndexA >= model.RigidBodies.Length() ||
+			joint.RigidBodyIndexB < 0 || joint.RigidBodyIndexB >= model.RigidBodies.Length():
+			issues = append(issues, entity.NewValidationIssue(
+				entity.ValidationSeverityError, entity.ValidationCodeJointMissingBody, joint.Name(),
+				map[string]any{"IndexA": joint.RigidBodyIndexA, "IndexB": joint.RigidBodyIndexB}))
+		case joint.RigidBodyIndexA == joint.RigidBodyIndexB:
+			issues = append(issues, entity.NewValidationIssue(
+				entity.ValidationSeverityError, entity.ValidationCodeJointSameBody, joint.Name(),
+				map[string]any{"IndexA": joint.RigidBodyIndexA, "IndexB": joint.RigidBodyIndexB}))
+		}
+
+		return true
+	})
+
+	return issues
+}
+
+// validateBones 物理ボーンの表示枠・ボーン名のバイト数を検査
+func (uc *ValidationUsecase) validateBones(model *pmx.PmxModel, boneNameMap map[string]string) []*entity.ValidationIssue {
+	issues := make([]*entity.ValidationIssue, 0)
+
+	// 焼き込み用の名前 → 元の名前
+	originalBoneNames := boneNameMap
+
+	// 元モデルの表示枠に登録されているボーン
+	//   - 読み込み時に物理ボーン用の表示枠を追加しているため、ボーンの DisplaySlotIndex ではなく、その表示枠以外の参照で判定する
+	displayedBones := make([]bool, model.Bones.Length())
+	model.DisplaySlots.ForEach(func(slotIndex int, slot *pmx.DisplaySlot) bool {
+		if slot.Name() == physicsDisplaySlotName {
+			return true
+		}
+		for _, ref := range slot.References {
+			if ref.DisplayType == pmx.DISPLAY_TYPE_BONE && ref.DisplayIndex >= 0 && ref.DisplayIndex < len(displayedBones) {
+				displayedBones[ref.DisplayIndex] = true
+			}
+		}
+		return true
+	})
+
+	model.Bones.ForEach(func(boneIndex int, bone *pmx.Bone) bool {
+		if bone.HasDynamicPhysics() && !displayedBones[boneIndex] {
+			// 表示枠に無いボーンは出力設定の対象にならない
+			issues = append(issues, entity.NewValidationIssue(
+				entity.ValidationSeverityWarning, entity.ValidationCodeBoneNoDisplaySlot, bone.Name(), nil))
+		}
+
+		// 改名している場合は、接頭辞を付けた切り詰め前の名前で判定する
+		fullName := bone.Name()
+		if originalBoneName, ok := originalBoneNames[bone.Name()]; ok {
+			fullName = bakeBonePrefix(bone.Name()) + originalBoneName
+		}
+
+		if length, ok := shiftJISByteLength(fullName); !ok || length > boneNameByteLimit {
+			issues = append(issues, entity.NewValidationIssue(
+				entity.ValidationSeverityWarning, entity.ValidationCodeBoneNameTooLong, fullName,
+				map[string]any{"BoneName": bone.Name(), "Limit": boneNameByteLimit}))
+		}
+
+		return true
+	})
+
+	return issues
+}
+
+// LogIssues 検査結果をログに出力
+func (uc *ValidationUsecase) LogIssues(issues []*entity.ValidationIssue) {
+	if len(issues) == 0 {
+		mlog.I(mi18n.T("モデル検査問題なし"))
+		return
+	}
+
+	mlog.W(mi18n.T("モデル検査問題あり", map[string]any{"Count": len(issues)}))
+	for _, issue := range issues {
+		severity := mi18n.T("警告")
+		if issue.Severity == entity.ValidationSeverityError {
+			severity = mi18n.T("エラー")
+		}
+		mlog.W(fmt.Sprintf("[%s] %s\n    -> %s", severity, issue.Message(), issue.Suggestion()))
+	}
+}
+
+// CheckCompatibility モーションのボーン・モーフのキーフレームが、モデルのどの名前と一致するかを調べる
+//   - boneNameMap は読み込み時の物理ボーンの改名対応表（焼き込み用の名前 → 元の名前）。改名したボーンは元の名前で照合する
+func (uc *ValidationUsecase) CheckCompatibility(
+	model *pmx.PmxModel, motion *vmd.VmdMotion, boneNameMap map[string]string,
+) *entity.CompatibilityReport {
+	if model == nil || motion == nil {
+		return nil
+	}
+
+	// 焼き込み用の名前 → 元の名前
+	originalBoneNames := boneNameMap
+
+	modelBoneNames := make([]string, 0, model.Bones.Length())
+	model.Bones.ForEach(func(boneIndex int, bone *pmx.Bone) bool {
+		if bone.IsSystem {
+			// ボーンベイカーが追加したボーンは対象外
+			return true
+		}
+		if originalBoneName, ok := originalBoneNames[bone.Name()]; ok {
+			modelBoneNames = append(modelBoneNames, originalBoneName)
+		} else {
+			modelBoneNames = append(modelBoneNames, bone.Name())
+		}
+		return true
+	})
+
+	report := &entity.CompatibilityReport{
+		MinFrame:    motion.MinFrame(),
+		MaxFrame:    motion.MaxFrame(),
+		Suggestions: make([]*entity.CompatibilitySuggestion, 0),
+	}
+
+	var suggestions []*entity.CompatibilitySuggestion
+	report.MatchedBoneNames, report.MissingBoneNames, report.ExtraBoneNames, suggestions =
+		compareNames(motion.BoneFrames.Names(), modelBoneNames, entity.CompatibilityTargetTypeBone)
+	report.Suggestions = append(report.Suggestions, suggestions...)
+
+	report.MatchedMorphNames, report.MissingMorphNames, report.ExtraMorphNames, suggestions =
+		compareNames(motion.MorphFrames.Names(), model.Morphs.Names(), entity.CompatibilityTargetTypeMorph)
+	report.Suggestions = append(report.Suggestions, suggestions...)
+
+	return report
+}
+
+// LogCompatibility モーションとモデルの対応状況をログに出力
+func (uc *ValidationUsecase) LogCompatibility(report *entity.CompatibilityReport) {
+	if report == nil {
+		return
+	}
+
+	mlog.I(mi18n.T("モーション互換性", map[string]any{
+		"MatchedBone":  len(report.MatchedBoneNames),
+		"MotionBone":   len(report.MatchedBoneNames) + len(report.MissingBoneNames),
+		"ExtraBone":    len(report.ExtraBoneNames),
+		"MatchedMorph": len(report.MatchedMorphNames),
+		"MotionMorph":  len(report.MatchedMorphNames) + len(report.MissingMorphNames),
+		"ExtraMorph":   len(report.ExtraMorphNames),
+		"MinFrame":     fmt.Sprintf("%.0f", report.MinFrame),
+		"MaxFrame":     fmt.Sprintf("%.0f", report.MaxFrame),
+	}))
+
+	if len(report.MissingBoneNames) > 0 {
+		mlog.W(mi18n.T("モデルに無いボーン", map[string]any{"Names": strings.Join(report.MissingBoneNames, ", ")}))
+	}
+	if len(report.MissingMorphNames) > 0 {
+		mlog.W(mi18n.T("モデルに無いモーフ", map[string]any{"Names": strings.Join(report.MissingMorphNames, ", ")}))
+	}
+
+	for _, suggestion := range report.Suggestions {
+		reason := mi18n.T("全角半角違い")
+		if suggestion.ReasonType == entity.CompatibilityReasonTypeDirection {
+			reason = mi18n.T("左右違い")
+		}
+		mlog.W(mi18n.T("名前候補", map[string]any{
+			"MotionName": suggestion.MotionName,
+			"ModelName":  suggestion.ModelName,
+			"Reason":     reason,
+		}))
+	}
+}
+
+// compareNames モーション側とモデル側の名前を照合し、一致・モデルに無い・モーションに無い名前と、表記揺れの候補を返す
+func compareNames(
+	motionNames, modelNames []string, targetType entity.CompatibilityTargetType,
+) (matched, missing, extra []string, suggestions []*entity.CompatibilitySuggestion) {
+	matched = make([]string, 0)
+	missing = make([]string, 0)
+	extra = make([]string, 0)
+	suggestions = make([]*entity.CompatibilitySuggestion, 0)
+
+	modelNameSet := make(map[string]bool, len(modelNames))
+	for _, name := range modelNames {
+		modelNameSet[name] = true
+	}
+	motionNameSet := make(map[string]bool, len(motionNames))
+	for _, name := range motionNames {
+		motionNameSet[name] = true
+	}
+
+	for name := range motionNameSet {
+		if modelNameSet[name] {
+			matched = append(matched, name)
+		} else {
+			missing = append(missing, name)
+		}
+	}
+	for name := range modelNameSet {
+		if !motionNameSet[name] {
+			extra = append(extra, name)
+		}
+	}
+	sort.Strings(matched)
+	sort.Strings(missing)
+	sort.Strings(extra)
+
+	// モーションに無いモデル側の名前から、表記揺れで一致するものを候補とする
+	for _, motionName := range missing {
+		normalizedName := normalizeName(motionName)
+		swappedName := normalizeName(swapDirection(motionName))
+		for _, modelName := range extra {
+			switch normalizeName(modelName) {
+			case normalizedName:
+				suggestions = append(suggestions, &entity.CompatibilitySuggestion{
+					TargetType: targetType,
+					MotionName: motionName,
+					ModelName:  modelName,
+					ReasonType: entity.CompatibilityReasonTypeWidth,
+				})
+			case swappedName:
+				suggestions = append(suggestions, &entity.CompatibilitySuggestion{
+					TargetType: targetType,
+					MotionName: motionName,
+					ModelName:  modelName,
+					ReasonType: entity.CompatibilityReasonTypeDirection,
+				})
+			}
+		}
+	}
+
+	return matched, missing, extra, suggestions
+}
+
+// normalizeName 全角・半角、大文字・小文字、空白の違いを無視して比較するための名前
+func normalizeName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(norm.NFKC.String(name)), ""))
+}
+
+// 左右を入れ替える
+var directionReplacer = strings.NewReplacer("左", "右", "右", "左")
+
+// swapDirection 名前の左右を入れ替える
+func swapDirection(name string) string {
+	return directionReplacer.Replace(name)
+}
+
+// 焼き込み用のボーン名の接頭辞（"BB01_" や、重複回避の連番付きの "BB01_1_"）
+var bakeBonePrefixPattern = regexp.MustCompile(`^BB\d+_(\d+_)?`)
+
+// bakeBonePrefix 焼き込み用のボーン名の接頭辞
+func bakeBonePrefix(bakeBoneName string) string {
+	return bakeBonePrefixPattern.FindString(bakeBoneName)
+}
+
+// shiftJISByteLength Shift-JISでのバイト数（表現できない文字を含む場合は false）
+func shiftJISByteLength(name string) (int, bool) {
+	encoded, err := japanese.ShiftJIS.NewEncoder().String(name)
+	if err != nil {
+		return len(name), false
+	}
+	return len(encoded), true
+}
@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/miu200521358/bone_baker/pkg/domain/entity"
+	"github.com/miu200521358/bone_baker/pkg/testutil"
+	"github.com/miu200521358/mlib_go/pkg/domain/pmx"
+)
+
+func TestValidationUsecase_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(model *pmx.PmxModel)
+		boneNameMap map[string]string
+		want        map[string]entity.ValidationSeverity // 対象名+コード → 重要度
+	}{
+		{
+			name:   "表示枠なしの物理ボーンのみ",
+			modify: func(model *pmx.PmxModel) {},
+			want: map[string]entity.ValidationSeverity{
+				testutil.BoneHair2 + entity.ValidationCodeBoneNoDisplaySlot: entity.ValidationSeverityWarning,
+			},
+		},
+		{
+			name: "読み込み時に追加した表示枠のみの物理ボーン",
+			modify: func(model *pmx.PmxModel) {
+				uc := &LoadUsecase{}
+				uc.appendPhysicsBoneToDisplaySlots(model)
+			},
+			want: map[string]entity.ValidationSeverity{
+				testutil.BoneHair2 + entity.ValidationCodeBoneNoDisplaySlot: entity.ValidationSeverityWarning,
+			},
+		},
+		{
+			name: "質量0の物理剛体とジョイントの剛体重複",
+			modify: func(model *pmx.PmxModel) {
+				rb, _ := model.RigidBodies.GetByName(testutil.RigidBodyHair1)
+				rb.RigidBodyParam.Mass = 0
+				joint, _ := model.Joints.Get(0)
+				joint.RigidBodyIndexB = joint.RigidBodyIndexA
+			},
+			want: map[string]entity.ValidationSeverity{
+				testutil.BoneHair2 + entity.ValidationCodeBoneNoDisplaySlot:  entity.ValidationSeverityWarning,
+				testutil.RigidBodyHair1 + entity.ValidationCodeRigidBodyMass: entity.ValidationSeverityError,
+				testutil.JointHair + entity.ValidationCodeJointSameBody:      entity.ValidationSeverityError,
+			},
+		},
+		{
+			name: "存在しない剛体を参照するジョイント",
+			modify: func(model *pmx.PmxModel) {
+				joint, _ := model.Joints.Get(0)
+				joint.RigidBodyIndexB = 5
+			},
+			want: map[string]entity.ValidationSeverity{
+				testutil.BoneHair2 + entity.ValidationCodeBoneNoDisplaySlot: entity.ValidationSeverityWarning,
+				testutil.JointHair + entity.ValidationCodeJointMissingBody:  entity.ValidationSeverityError,
+			},
+		},
+		{
+			name: "改名で切り詰められたボーン名",
+			modify: func(model *pmx.PmxModel) {
+				bone, _ := model.Bones.GetByName(testutil.BoneHair1)
+				bone.SetName("BB2_あいうえ")
+				model.Bones.UpdateNameIndexes()
+			},
+			boneNameMap: map[string]string{"BB2_あいうえ": "あいうえおかき"},
+			want: map[string]entity.ValidationSeverity{
+				testutil.BoneHair2 + entity.ValidationCodeBoneNoDisplaySlot: entity.ValidationSeverityWarning,
+				"BB2_あいうえおかき" + entity.ValidationCodeBoneNameTooLong:        entity.ValidationSeverityWarning,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := testutil.NewModel()
+			tt.modify(model)
+
+			uc := NewValidationUsecase()
+			issues := uc.Validate(model, tt.boneNameMap)
+
+			got := make(map[string]entity.ValidationSeverity)
+			for _, issue := range issues {
+				got[issue.Target+issue.Code] = issue.Severity
+			}
+
+			if len(got) != len(tt.want) {
+				t.Errorf("issues = %v, want %v", got, tt.want)
+			}
+			for key, severity := range tt.want {
+				if got[key] != severity {
+					t.Errorf("%s severity = %d, want %d", key, got[key], severity)
+				}
+			}
+		})
+	}
+}
+
+func TestCompareNames(t *testing.T) {
+	motionNames := []string{"センター", "ｸﾞﾙｰﾌﾞ", "右腕", "左足ＩＫ", "全ての親"}
+	modelNames := []string{"センター", "グルーブ", "左腕", "左足IK", "上半身"}
+
+	matched, missing, extra, suggestions := compareNames(motionNames, modelNames, entity.CompatibilityTargetTypeBone)
+
+	if want := []string{"センター"}; !slices.Equal(matched, want) {
+		t.Errorf("matched = %v, want %v", matched, want)
+	}
+	if want := []string{"全ての親", "右腕", "左足ＩＫ", "ｸﾞﾙｰﾌﾞ"}; !slices.Equal(missing, want) {
+		t.Errorf("missing = %v, want %v", missing, want)
+	}
+	if want := []string{"グルーブ", "上半身", "左腕", "左足IK"}; !slices.Equal(extra, want) {
+		t.Errorf("extra = %v, want %v", extra, want)
+	}
+
+	got := make(map[string]entity.CompatibilityReasonType)
+	for _, suggestion := range suggestions {
+		got[suggestion.MotionName+"->"+suggestion.ModelName] = suggestion.ReasonType
+	}
+	want := map[string]entity.CompatibilityReasonType{
+		"ｸﾞﾙｰﾌﾞ->グルーブ": entity.CompatibilityReasonTypeWidth,
+		"右腕->左腕":       entity.CompatibilityReasonTypeDirection,
+		"左足ＩＫ->左足IK":   entity.CompatibilityReasonTypeWidth,
+	}
+	if !maps.Equal(got, want) {
+		t.Errorf("suggestions = %v, want %v", got, want)
+	}
+}
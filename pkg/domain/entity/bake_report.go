@@ -0,0 +1,33 @@
+package entity
+
+// BakeReport 焼き込み結果の報告
+type BakeReport struct {
+	ModelPath          string                `json:"model_path"`           // 元モデルパス
+	OriginalMotionPath string                `json:"original_motion_path"` // 元モーションパス
+	OutputMotionPath   string                `json:"output_motion_path"`   // 出力モーションパス（分割前）
+	Files              []*BakeReportFile     `json:"files"`                // 出力ファイル一覧（分割順）
+	Bones              []*BakeReportBone     `json:"bones"`                // ボーン毎の出力内容
+	Reduces            []*OutputReduceReport `json:"reduces"`              // ボーン毎の間引き結果
+	BoneNameMap        map[string]string     `json:"bone_name_map"`        // 焼き込み用の名前 → 物理ボーンの元の名前
+	BoneAliasMap       map[string]string     `json:"bone_alias_map"`       // 別名を適用したモーションのボーン名 → モデルのボーン名
+}
+
+// BakeReportFile 分割出力した1ファイル分の報告
+type BakeReportFile struct {
+	Path          string         `json:"path"`            // 出力パス
+	Group         string         `json:"group,omitempty"` // ボーングループ（ボーングループ毎に分割した場合）
+	StartFrame    float32        `json:"start_frame"`     // 開始フレーム
+	EndFrame      float32        `json:"end_frame"`       // 終了フレーム
+	KeyCount      int            `json:"key_count"`       // キーフレーム数
+	BoneKeyCounts map[string]int `json:"bone_key_counts"` // ボーン毎のキーフレーム数
+}
+
+// BakeReportBone 1ボーン分の報告
+type BakeReportBone struct {
+	BoneName           string `json:"bone_name"`                    // ボーン名
+	OriginalBoneName   string `json:"original_bone_name,omitempty"` // 元モデルでのボーン名（焼き込み用に改名した場合）
+	OriginalFrameCount int    `json:"original_frame_count"`         // 元モーションから出力したフレーム数
+	BakeFrameCount     int    `json:"bake_frame_count"`             // 焼き込み出力したフレーム数
+	ReduceFrameCount   int    `json:"reduce_frame_count"`           // 間引き対象のフレーム数
+	KeyCount           int    `json:"key_count"`                    // 全ファイルの出力キーフレーム数
+}
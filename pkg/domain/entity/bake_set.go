@@ -12,10 +12,12 @@ import (
 type BakeSet struct {
 	Index int // インデックス
 
-	OriginalMotionPath string `json:"original_motion_path"` // 元モーションパス
-	OriginalModelPath  string `json:"original_model_path"`  // 元モデルパス
-	OutputMotionPath   string `json:"-"`                    // 出力モーションパス
-	OutputModelPath    string `json:"-"`                    // 出力モデルパス
+	OriginalMotionPath string          `json:"original_motion_path"` // 元モーションパス
+	MergeMotionPaths   []string        `json:"merge_motion_paths"`   // 元モーションに重ねるモーションパス（先頭から順に重ねる）
+	MotionMergeType    MotionMergeType `json:"motion_merge_type"`    // モーションの重ね方
+	OriginalModelPath  string          `json:"original_model_path"`  // 元モデルパス
+	OutputMotionPath   string          `json:"-"`                    // 出力モーションパス
+	OutputModelPath    string          `json:"-"`                    // 出力モデルパス
 
 	OriginalMotion *vmd.VmdMotion `json:"-"` // 元モデル
 	OriginalModel  *pmx.PmxModel  `json:"-"` // 元モデル
@@ -24,12 +26,34 @@ type BakeSet struct {
 
 	RigidBodyRecords []*RigidBodyRecord `json:"rigid_body_records"` // モデル物理設定レコード
 	OutputRecords    []*OutputRecord    `json:"output_records"`     // 出力設定レコード
+	WindScope        WindScope          `json:"wind_scope"`         // 風設定の適用範囲
+	WindRecords      []*WindRecord      `json:"wind_records"`       // セット個別の風設定レコード
+	OutputSplit      *OutputSplit       `json:"output_split"`       // 出力モーションの分割方法
+
+	OutputBoneNameType OutputBoneNameType `json:"output_bone_name_type"` // 出力モーションのボーン名
+	OutputCleanModel   bool               `json:"output_clean_model"`    // システム用の追加を除いた出力モデルを保存するか
+	OutputPhysics      *OutputPhysics     `json:"output_physics"`        // 出力モデルへのモデル物理設定の書き込み方法
+
+	CollisionProxyProfile *CollisionProxyProfile `json:"collision_proxy_profile"` // 衝突用剛体の設定
+	BoneNameMap           map[string]string      `json:"bone_name_map"`           // 焼き込み用の名前 → 物理ボーンの元の名前（元の名前は重複し得る）
+
+	BoneAliasPaths []string          `json:"bone_alias_paths"` // ユーザー定義のボーン別名ファイルパス
+	BoneAliasMap   map[string]string `json:"-"`                // 別名を適用したモーションのボーン名 → モデルのボーン名
 }
 
 func NewBakeSet(index int) *BakeSet {
 	return &BakeSet{
-		Index:          index,
-		OriginalMotion: vmd.NewVmdMotion(""),
+		Index:            index,
+		OriginalMotion:   vmd.NewVmdMotion(""),
+		MergeMotionPaths: make([]string, 0),
+		OutputSplit:      NewOutputSplit(),
+		OutputPhysics:    NewOutputPhysics(),
+
+		CollisionProxyProfile: NewCollisionProxyProfile(),
+		BoneNameMap:           make(map[string]string),
+
+		BoneAliasPaths: make([]string, 0),
+		BoneAliasMap:   make(map[string]string),
 	}
 }
 
@@ -37,8 +61,18 @@ func (s *BakeSet) Clear() {
 	s.ClearModel()
 	s.ClearMotion()
 
+	s.MergeMotionPaths = make([]string, 0)
+	s.MotionMergeType = MotionMergeTypeOverride
 	s.RigidBodyRecords = make([]*RigidBodyRecord, 0)
 	s.OutputRecords = make([]*OutputRecord, 0)
+	s.WindScope = WindScopeGlobal
+	s.WindRecords = make([]*WindRecord, 0)
+	s.OutputSplit = NewOutputSplit()
+	s.OutputBoneNameType = OutputBoneNameTypeBake
+	s.OutputCleanModel = false
+	s.OutputPhysics = NewOutputPhysics()
+	s.CollisionProxyProfile = NewCollisionProxyProfile()
+	s.BoneAliasPaths = make([]string, 0)
 }
 
 func (s *BakeSet) ClearModel() {
@@ -46,6 +80,7 @@ func (s *BakeSet) ClearModel() {
 	s.BakedModel = nil
 	s.OriginalModelPath = ""
 	s.OutputModelPath = ""
+	s.BoneNameMap = make(map[string]string)
 }
 
 func (s *BakeSet) ClearMotion() {
@@ -54,6 +89,22 @@ func (s *BakeSet) ClearMotion() {
 	s.OutputMotion = nil
 	s.OriginalMotionPath = ""
 	s.OutputMotionPath = ""
+	s.BoneAliasMap = make(map[string]string)
+}
+
+// RestoreRecordTrees 各レコードのツリーを現在の元モデルに紐付け直す
+func (s *BakeSet) RestoreRecordTrees() {
+	if s.OriginalModel == nil {
+		return
+	}
+
+	for _, record := range s.RigidBodyRecords {
+		record.Restore(s.OriginalModel)
+	}
+
+	for _, record := range s.OutputRecords {
+		record.Restore(s.OriginalModel)
+	}
 }
 
 func (s *BakeSet) OriginalMotionName() string {
@@ -0,0 +1,92 @@
+package entity
+
+// BoneAliasTable 同じボーンを指す名前の組の一覧
+//   - 組の中の名前は互いに読み替え可能として扱う
+//   - 全角・半角、大文字・小文字、空白の違いは照合時に無視される
+type BoneAliasTable struct {
+	Groups [][]string `json:"groups"` // 同じボーンを指す名前の組
+}
+
+// 標準ボーンの別名（左右のあるボーンは、英語名に「_L」「_R」、「left 」「right 」を付けた名前も別名とする）
+var standardBoneAliases = []struct {
+	name      string
+	aliases   []string // 日本語の別名は左右を前に付け、英語名は後ろに付ける
+	direction bool     // 左右のあるボーンか
+}{
+	{"全ての親", []string{"全親", "マスター", "master", "mother"}, false},
+	{"センター", []string{"center"}, false},
+	{"グルーブ", []string{"グルーヴ", "groove"}, false},
+	{"腰", []string{"waist"}, false},
+	{"上半身", []string{"upper body", "upperbody", "upper_body"}, false},
+	{"上半身2", []string{"upper body2", "upperbody2", "upper_body2"}, false},
+	{"下半身", []string{"lower body", "lowerbody", "lower_body"}, false},
+	{"首", []string{"neck"}, false},
+	{"頭", []string{"head"}, false},
+	{"両目", []string{"eyes"}, false},
+	{"目", []string{"eye"}, true},
+	{"肩", []string{"shoulder"}, true},
+	{"腕", []string{"arm"}, true},
+	{"ひじ", []string{"肘", "elbow"}, true},
+	{"手首", []string{"wrist"}, true},
+	{"足", []string{"leg"}, true},
+	{"ひざ", []string{"膝", "knee"}, true},
+	{"足首", []string{"ankle"}, true},
+	{"足ＩＫ", []string{"leg ik", "leg_ik"}, true},
+	{"つま先ＩＫ", []string{"toe ik", "toe_ik"}, true},
+}
+
+// NewBoneAliasTable 標準ボーンの表記揺れ・英語名を登録した別名表
+func NewBoneAliasTable() *BoneAliasTable {
+	table := &BoneAliasTable{Groups: make([][]string, 0)}
+
+	for _, standard := range standardBoneAliases {
+		if !standard.direction {
+			table.Append(append([]string{standard.name}, standard.aliases...)...)
+			continue
+		}
+
+		for _, direction := range []struct{ jp, suffix, prefix string }{
+			{"左", "_L", "left "},
+			{"右", "_R", "right "},
+		} {
+			names := []string{direction.jp + standard.name}
+			for _, alias := range standard.aliases {
+				if isASCII(alias) {
+					names = append(names, alias+direction.suffix, direction.prefix+alias)
+				} else {
+					names = append(names, direction.jp+alias)
+				}
+			}
+			table.Append(names...)
+		}
+	}
+
+	return table
+}
+
+// Append 同じボーンを指す名前の組を追加
+func (t *BoneAliasTable) Append(names ...string) {
+	if len(names) < 2 {
+		return
+	}
+	t.Groups = append(t.Groups, names)
+}
+
+// Merge 別の別名表の組を追加
+func (t *BoneAliasTable) Merge(other *BoneAliasTable) {
+	if other == nil {
+		return
+	}
+	for _, names := range other.Groups {
+		t.Append(names...)
+	}
+}
+
+func isASCII(s string) bool {
+	for _, r := range s {
+		if r > 0x7f {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,104 @@
+package entity
+
+import (
+	"errors"
+
+	"github.com/miu200521358/mlib_go/pkg/config/mi18n"
+	"github.com/miu200521358/mlib_go/pkg/domain/mmath"
+	"github.com/miu200521358/mlib_go/pkg/domain/pmx"
+)
+
+type CollisionProxyShape = int
+
+const (
+	CollisionProxyShapeSphere  CollisionProxyShape = 0 // 球
+	CollisionProxyShapeBox     CollisionProxyShape = 1 // 箱
+	CollisionProxyShapeCapsule CollisionProxyShape = 2 // カプセル
+)
+
+type CollisionProxySizeType = int
+
+const (
+	CollisionProxySizeTypeVertex CollisionProxySizeType = 0 // ウェイト頂点の（中央値－最小値）×倍率
+	CollisionProxySizeTypeFixed  CollisionProxySizeType = 1 // 固定サイズ
+	CollisionProxySizeTypeFit    CollisionProxySizeType = 2 // ウェイト頂点の主成分軸に沿って位置・向き・大きさを当てはめ×倍率
+)
+
+// 衝突用剛体の名前接頭辞
+const CollisionProxyPrefix = "BBJ_"
+
+// 剛体の衝突グループ数
+const collisionGroupCount = 16
+
+// CollisionProxyProfile 物理ボーンとの接触判定用に、元モデルへ追加する剛体の設定
+type CollisionProxyProfile struct {
+	Items []*CollisionProxyItem `json:"items"` // 剛体を追加するボーン毎の設定
+}
+
+// CollisionProxyItem 1ボーン分の衝突用剛体設定
+type CollisionProxyItem struct {
+	BoneName           string                 `json:"bone_name"`            // 剛体を追加するボーン名
+	Shape              CollisionProxyShape    `json:"shape"`                // 剛体形状
+	SizeType           CollisionProxySizeType `json:"size_type"`            // 大きさの決め方
+	SizeRatio          float64                `json:"size_ratio"`           // 頂点から算出する場合の倍率
+	Size               *mmath.MVec3           `json:"size"`                 // 固定サイズ（頂点が無い場合も使用）
+	CollisionGroup     int                    `json:"collision_group"`      // 衝突グループ (0～15)
+	CollisionGroupMask []uint16               `json:"collision_group_mask"` // 非衝突グループ (16グループ分、1: 衝突しない)
+}
+
+// NewCollisionProxyItem 従来の衝突用剛体と同じ設定（球・頂点から算出・床剛体と同じグループ）
+func NewCollisionProxyItem(boneName string) *CollisionProxyItem {
+	return &CollisionProxyItem{
+		BoneName:           boneName,
+		Shape:              CollisionProxyShapeSphere,
+		SizeType:           CollisionProxySizeTypeVertex,
+		SizeRatio:          0.3,
+		Size:               &mmath.MVec3{X: 0.2, Y: 0.2, Z: 0.2},
+		CollisionGroup:     15, // 床剛体と同レベルで接触判定させる
+		CollisionGroupMask: []uint16{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+	}
+}
+
+// NewCollisionProxyFitItem ウェイト頂点の主成分軸に当てはめる衝突用剛体設定（前腕・すね・太ももなど向け）
+func NewCollisionProxyFitItem(boneName string, shape CollisionProxyShape) *CollisionProxyItem {
+	item := NewCollisionProxyItem(boneName)
+	item.Shape = shape
+	item.SizeType = CollisionProxySizeTypeFit
+	item.SizeRatio = 1.0
+	return item
+}
+
+// NewCollisionProxyProfile 初期設定
+//   - 太もも・すね・前腕は、ウェイト頂点に当てはめたカプセル（足・ひざ・ひじ）
+//   - それ以外は球（足首・つま先・かかと・手首・目）
+//   - いずれも左右
+func NewCollisionProxyProfile() *CollisionProxyProfile {
+	profile := &CollisionProxyProfile{Items: make([]*CollisionProxyItem, 0)}
+
+	for _, direction := range []pmx.BoneDirection{pmx.BONE_DIRECTION_LEFT, pmx.BONE_DIRECTION_RIGHT} {
+		for _, boneName := range []pmx.StandardBoneName{pmx.LEG, pmx.KNEE, pmx.ELBOW} {
+			profile.Items = append(profile.Items,
+				NewCollisionProxyFitItem(boneName.StringFromDirection(direction), CollisionProxyShapeCapsule))
+		}
+		for _, boneName := range []pmx.StandardBoneName{pmx.ANKLE, pmx.TOE_T, pmx.HEEL, pmx.WRIST, pmx.EYE} {
+			profile.Items = append(profile.Items, NewCollisionProxyItem(boneName.StringFromDirection(direction)))
+		}
+	}
+
+	return profile
+}
+
+// Validate 設定ファイルから読み込んだ衝突グループ・非衝突グループが剛体に設定できる値か確認する
+func (p *CollisionProxyProfile) Validate() error {
+	for _, item := range p.Items {
+		if item.CollisionGroup < 0 || item.CollisionGroup >= collisionGroupCount {
+			return errors.New(mi18n.T("衝突用剛体衝突グループエラー", map[string]any{
+				"BoneName": item.BoneName, "Group": item.CollisionGroup}))
+		}
+		if len(item.CollisionGroupMask) != collisionGroupCount {
+			return errors.New(mi18n.T("衝突用剛体非衝突グループエラー", map[string]any{
+				"BoneName": item.BoneName, "Count": len(item.CollisionGroupMask)}))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package entity
+
+type CompatibilityTargetType = int
+
+const (
+	CompatibilityTargetTypeBone  CompatibilityTargetType = 0 // ボーン
+	CompatibilityTargetTypeMorph CompatibilityTargetType = 1 // モーフ
+)
+
+type CompatibilityReasonType = int
+
+const (
+	CompatibilityReasonTypeWidth     CompatibilityReasonType = 0 // 全角・半角（大文字・小文字、空白）の違い
+	CompatibilityReasonTypeDirection CompatibilityReasonType = 1 // 左・右の違い
+)
+
+// CompatibilityReport 読み込んだモーションとモデルの名前の対応状況
+//   - Missing: モーションにキーフレームがあるが、モデルに無い名前（焼き込み時に無視される）
+//   - Extra: モデルにあるが、モーションにキーフレームが無い名前
+type CompatibilityReport struct {
+	MatchedBoneNames  []string                   `json:"matched_bone_names"`  // 一致したボーン名
+	MissingBoneNames  []string                   `json:"missing_bone_names"`  // モデルに無いボーン名
+	ExtraBoneNames    []string                   `json:"extra_bone_names"`    // モーションに無いボーン名
+	MatchedMorphNames []string                   `json:"matched_morph_names"` // 一致したモーフ名
+	MissingMorphNames []string                   `json:"missing_morph_names"` // モデルに無いモーフ名
+	ExtraMorphNames   []string                   `json:"extra_morph_names"`   // モーションに無いモーフ名
+	MinFrame          float32                    `json:"min_frame"`           // モーションの開始フレーム
+	MaxFrame          float32                    `json:"max_frame"`           // モーションの終了フレーム
+	Suggestions       []*CompatibilitySuggestion `json:"suggestions"`         // 名前の候補
+}
+
+// CompatibilitySuggestion モデルに無い名前に対して、表記揺れで一致しそうなモデル側の名前
+type CompatibilitySuggestion struct {
+	TargetType CompatibilityTargetType `json:"target_type"` // ボーン・モーフ
+	MotionName string                  `json:"motion_name"` // モーション側の名前
+	ModelName  string                  `json:"model_name"`  // モデル側の候補名
+	ReasonType CompatibilityReasonType `json:"reason_type"` // 候補とした理由
+}
+
+// IsFullyMatched モーションの全てのボーン・モーフがモデルに存在するか
+func (r *CompatibilityReport) IsFullyMatched() bool {
+	return len(r.MissingBoneNames) == 0 && len(r.MissingMorphNames) == 0
+}
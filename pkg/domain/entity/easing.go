@@ -0,0 +1,200 @@
+package entity
+
+import "math"
+
+type EasingType = int
+
+const (
+	EasingTypeLinear    EasingType = 0 // 線形
+	EasingTypeEaseIn    EasingType = 1 // 徐々に加速
+	EasingTypeEaseOut   EasingType = 2 // 徐々に減速
+	EasingTypeEaseInOut EasingType = 3 // 加速して減速
+	EasingTypeStep      EasingType = 4 // 区間の最後で切り替え
+	EasingTypeBezier    EasingType = 5 // 任意のベジェ曲線
+)
+
+// VMDの補間曲線と同じく、制御点は 0～127 の範囲で指定する
+const easingCurveMax = 127
+
+// Easing 台形の変化区間（開始→最大開始、最大終了→終了）の補間方法
+type Easing struct {
+	Type EasingType `json:"type"` // 補間種別
+	X1   int        `json:"x1"`   // 制御点1 X (0～127)
+	Y1   int        `json:"y1"`   // 制御点1 Y (0～127)
+	X2   int        `json:"x2"`   // 制御点2 X (0～127)
+	Y2   int        `json:"y2"`   // 制御点2 Y (0～127)
+}
+
+func NewEasing(easingType EasingType) *Easing {
+	e := &Easing{Type: easingType}
+
+	switch easingType {
+	case EasingTypeEaseIn:
+		e.X1, e.Y1, e.X2, e.Y2 = 64, 0, 127, 127
+	case EasingTypeEaseOut:
+		e.X1, e.Y1, e.X2, e.Y2 = 0, 0, 64, 127
+	case EasingTypeEaseInOut:
+		e.X1, e.Y1, e.X2, e.Y2 = 64, 0, 64, 127
+	default:
+		// VMDの線形補間と同じ制御点
+		e.X1, e.Y1, e.X2, e.Y2 = 20, 20, 107, 107
+	}
+
+	return e
+}
+
+// IsLinear 線形補間か否か（未設定の場合も線形とみなす）
+func (e *Easing) IsLinear() bool {
+	return e == nil || e.Type == EasingTypeLinear
+}
+
+// Ratio 変化区間の経過割合 t (0～1) に対する変化量の割合 (0～1) を返す
+func (e *Easing) Ratio(t float64) float64 {
+	t = math.Max(0, math.Min(1, t))
+
+	if e.IsLinear() {
+		return t
+	}
+
+	if e.Type == EasingTypeStep {
+		if t < 1 {
+			return 0
+		}
+		return 1
+	}
+
+	x1 := float64(e.X1) / easingCurveMax
+	y1 := float64(e.Y1) / easingCurveMax
+	x2 := float64(e.X2) / easingCurveMax
+	y2 := float64(e.Y2) / easingCurveMax
+
+	return cubicBezier(bezierParameter(t, x1, x2), y1, y2)
+}
+
+// cubicBezier 始点0・終点1の3次ベジェ曲線の値
+func cubicBezier(s, p1, p2 float64) float64 {
+	is := 1 - s
+	return 3*is*is*s*p1 + 3*is*s*s*p2 + s*s*s
+}
+
+// 補間曲線の当てはめに使う途中の値の最大数
+const fitEasingSampleCount = 64
+
+// FitEasing 経過割合 ts に対する変化量の割合 ps に最も近いベジェ曲線の補間方法を求める
+//   - ts, ps は区間の両端（0, 1）を除いた途中の値
+//   - 制御点の X を格子状に探索し、X 毎に Y を最小二乗法で求める
+//   - 線形補間の方が誤差が小さい場合は線形を返す
+//   - 途中の値が多い場合は等間隔に間引いて探索する
+func FitEasing(ts, ps []float64) *Easing {
+	if len(ts) > fitEasingSampleCount {
+		sampledTs := make([]float64, fitEasingSampleCount)
+		sampledPs := make([]float64, fitEasingSampleCount)
+		for i := range fitEasingSampleCount {
+			j := i * len(ts) / fitEasingSampleCount
+			sampledTs[i], sampledPs[i] = ts[j], ps[j]
+		}
+		ts, ps = sampledTs, sampledPs
+	}
+
+	best := NewEasing(EasingTypeLinear)
+	bestError := best.MaxError(ts, ps)
+	if len(ts) == 0 || bestError < 1e-6 {
+		return best
+	}
+
+	us := make([]float64, len(ts))
+	try := func(x1, x2 int) bool {
+		if x1 < 0 || x1 > easingCurveMax || x2 < 0 || x2 > easingCurveMax {
+			return false
+		}
+
+		// X が経過割合となる曲線上の媒介変数から、Y の制御点を求める
+		for i, t := range ts {
+			us[i] = bezierParameter(t, float64(x1)/easingCurveMax, float64(x2)/easingCurveMax)
+		}
+		y1, y2 := fitBezierControls(us, ps)
+
+		e := &Easing{Type: EasingTypeBezier, X1: x1, Y1: curveValue(y1), X2: x2, Y2: curveValue(y2)}
+		if fitError := e.MaxError(ts, ps); fitError < bestError {
+			best = e
+			bestError = fitError
+			return true
+		}
+		return false
+	}
+
+	// 粗い格子で探索
+	for x1 := 0; x1 <= easingCurveMax; x1 += 16 {
+		for x2 := 0; x2 <= easingCurveMax; x2 += 16 {
+			try(x1, x2)
+		}
+	}
+
+	// 最も誤差の小さい制御点の周囲を、間隔を狭めながら探索
+	for step := 8; step >= 1; step /= 2 {
+		for improved := true; improved; {
+			x1, x2 := best.X1, best.X2
+			improved = try(x1-step, x2) || try(x1+step, x2) || try(x1, x2-step) || try(x1, x2+step)
+		}
+	}
+
+	return best
+}
+
+// MaxError 経過割合 ts に対する変化量の割合と ps との最大誤差
+func (e *Easing) MaxError(ts, ps []float64) float64 {
+	maxError := 0.0
+	for i, t := range ts {
+		maxError = math.Max(maxError, math.Abs(e.Ratio(t)-ps[i]))
+	}
+	return maxError
+}
+
+// fitBezierControls 媒介変数 us における値が vs に最も近くなる、始点0・終点1の3次ベジェ曲線の制御点
+func fitBezierControls(us, vs []float64) (p1, p2 float64) {
+	var a11, a12, a22, b1, b2 float64
+	for i, u := range us {
+		iu := 1 - u
+		c1 := 3 * iu * iu * u
+		c2 := 3 * iu * u * u
+		r := vs[i] - u*u*u
+
+		a11 += c1 * c1
+		a12 += c1 * c2
+		a22 += c2 * c2
+		b1 += c1 * r
+		b2 += c2 * r
+	}
+
+	det := a11*a22 - a12*a12
+	if math.Abs(det) < 1e-12 {
+		// 解が定まらない場合は直線
+		return 1.0 / 3, 2.0 / 3
+	}
+
+	return (b1*a22 - b2*a12) / det, (a11*b2 - a12*b1) / det
+}
+
+// bezierParameter X が t となる曲線上の媒介変数を二分探索で求める
+func bezierParameter(t, x1, x2 float64) float64 {
+	low, high := 0.0, 1.0
+	s := t
+	for range 32 {
+		x := cubicBezier(s, x1, x2)
+		if math.Abs(x-t) < 1e-6 {
+			break
+		}
+		if x < t {
+			low = s
+		} else {
+			high = s
+		}
+		s = (low + high) / 2
+	}
+	return s
+}
+
+// curveValue 0～1 の制御点を 0～127 に丸める
+func curveValue(v float64) int {
+	return int(math.Round(math.Max(0, math.Min(1, v)) * easingCurveMax))
+}
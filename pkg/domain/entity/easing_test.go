@@ -0,0 +1,44 @@
+package entity
+
+import "testing"
+
+func TestFitEasing(t *testing.T) {
+	// 既知の補間曲線から途中の値を作る
+	samples := func(e *Easing) (ts, ps []float64) {
+		for i := 1; i < 30; i++ {
+			t := float64(i) / 30
+			ts = append(ts, t)
+			ps = append(ps, e.Ratio(t))
+		}
+		return ts, ps
+	}
+
+	tests := []struct {
+		name     string
+		source   *Easing
+		wantType EasingType
+	}{
+		{name: "線形", source: NewEasing(EasingTypeLinear), wantType: EasingTypeLinear},
+		{name: "イーズイン", source: NewEasing(EasingTypeEaseIn), wantType: EasingTypeBezier},
+		{name: "イーズインアウト", source: NewEasing(EasingTypeEaseInOut), wantType: EasingTypeBezier},
+		{name: "任意のベジェ曲線", source: &Easing{Type: EasingTypeBezier, X1: 10, Y1: 90, X2: 40, Y2: 120}, wantType: EasingTypeBezier},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts, ps := samples(tt.source)
+			got := FitEasing(ts, ps)
+
+			if got.Type != tt.wantType {
+				t.Errorf("type = %d, want %d", got.Type, tt.wantType)
+			}
+			if maxError := got.MaxError(ts, ps); maxError > 0.01 {
+				t.Errorf("max error = %f, want <= 0.01 (%+v)", maxError, got)
+			}
+		})
+	}
+
+	if got := FitEasing(nil, nil); !got.IsLinear() {
+		t.Errorf("empty samples = %+v, want linear", got)
+	}
+}
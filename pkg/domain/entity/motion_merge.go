@@ -0,0 +1,8 @@
+package entity
+
+type MotionMergeType = int
+
+const (
+	MotionMergeTypeOverride MotionMergeType = 0 // 後のファイルにあるボーン・モーフは、後のファイルのキーフレームで置き換え
+	MotionMergeTypeUnion    MotionMergeType = 1 // 全ファイルのキーフレームを合わせる（同じフレームは後のファイルを優先）
+)
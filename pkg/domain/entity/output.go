@@ -17,21 +17,92 @@ const (
 	OutputBoneFlagReduce   OutputBoneFlag = 4 // 間引き出力
 )
 
+type OutputBoneNameType = int
+
+const (
+	OutputBoneNameTypeBake     OutputBoneNameType = 0 // 焼き込み用に改名したボーン名で出力（出力モデル用）
+	OutputBoneNameTypeOriginal OutputBoneNameType = 1 // 元モデルのボーン名で出力（未加工モデル用）
+)
+
+type OutputReduceType = int
+
+const (
+	OutputReduceTypeLinear OutputReduceType = 0 // 線形補間で誤差が収まるキーフレームを残す
+	OutputReduceTypeCurve  OutputReduceType = 1 // 補間曲線を当てはめて誤差が収まるキーフレームを残す
+)
+
+// 間引き許容誤差の初期値
+const (
+	DefaultReducePositionTolerance = 0.05 // 位置誤差
+	DefaultReduceRotationTolerance = 0.5  // 回転誤差（度）
+	DefaultReduceMaxInterval       = 0    // 最大キーフレーム間隔（0: 制限なし）
+)
+
+// 加算焼き込みの割合の初期値
+const DefaultAdditiveWeight = 1.0
+
 type OutputRecord struct {
-	StartFrame float32     `json:"start_frame"` // 区間開始フレーム
-	EndFrame   float32     `json:"end_frame"`   // 区間終了フレーム
-	Reduce     bool        `json:"reduce"`      // 間引き有無
-	Tree       *OutputTree `json:"items"`       // ボーンアイテム一覧
+	StartFrame              float32          `json:"start_frame"`               // 区間開始フレーム
+	EndFrame                float32          `json:"end_frame"`                 // 区間終了フレーム
+	Reduce                  bool             `json:"reduce"`                    // 間引き有無
+	ReducePositionTolerance float64          `json:"reduce_position_tolerance"` // 間引き許容位置誤差
+	ReduceRotationTolerance float64          `json:"reduce_rotation_tolerance"` // 間引き許容回転誤差（度）
+	ReduceMaxInterval       float64          `json:"reduce_max_interval"`       // 間引き最大キーフレーム間隔（0: 制限なし）
+	ReduceType              OutputReduceType `json:"reduce_type"`               // 間引き方法（線形・補間曲線）
+	Additive                bool             `json:"additive"`                  // 元モーションのキーフレームに加算して焼き込むか
+	AdditiveWeight          float64          `json:"additive_weight"`           // 加算する焼き込み結果の割合（0～1）
+	BlendInFrames           float64          `json:"blend_in_frames"`           // 区間開始から焼き込み結果に切り替えるフレーム数（0: 切り替えなし）
+	BlendOutFrames          float64          `json:"blend_out_frames"`          // 区間終了までに元モーションに戻すフレーム数（0: 切り替えなし）
+	Tree                    *OutputTree      `json:"items"`                     // ボーンアイテム一覧
 }
 
 func NewOutputRecord(startFrame, endFrame float32, model *pmx.PmxModel) *OutputRecord {
 	return &OutputRecord{
-		StartFrame: startFrame,
-		EndFrame:   endFrame,
-		Tree:       newOutputTree(model),
+		StartFrame:              startFrame,
+		EndFrame:                endFrame,
+		ReducePositionTolerance: DefaultReducePositionTolerance,
+		ReduceRotationTolerance: DefaultReduceRotationTolerance,
+		ReduceMaxInterval:       DefaultReduceMaxInterval,
+		AdditiveWeight:          DefaultAdditiveWeight,
+		Tree:                    newOutputTree(model),
 	}
 }
 
+// Restore 設定ファイルから読み込んだ出力設定をモデルのボーンに紐付け直す
+func (r *OutputRecord) Restore(model *pmx.PmxModel) {
+	if model == nil {
+		return
+	}
+
+	checkedNames := make([]string, 0)
+	if r.Tree != nil {
+		for _, item := range r.Tree.Items {
+			checkedNames = append(checkedNames, item.checkedBoneNames()...)
+		}
+	}
+
+	r.Tree = newOutputTree(model)
+	for _, item := range r.Tree.Items {
+		item.setCheckedByBoneNames(checkedNames)
+	}
+}
+
+// BlendWeight 指定フレームで焼き込み結果を反映する割合（0～1）
+//   - ブレンドイン区間では開始から徐々に、ブレンドアウト区間では終了に向けて徐々に元モーションに近付ける
+//   - 区間の端のフレームも元モーションそのものにはならないよう、ブレンドフレーム数+1で割る
+func (r *OutputRecord) BlendWeight(frame float32) float64 {
+	weight := 1.0
+
+	if r.BlendInFrames > 0 {
+		weight = min(weight, float64(frame-r.StartFrame+1)/(r.BlendInFrames+1))
+	}
+	if r.BlendOutFrames > 0 {
+		weight = min(weight, float64(r.EndFrame-frame+1)/(r.BlendOutFrames+1))
+	}
+
+	return max(0, weight)
+}
+
 func (r *OutputRecord) ItemNames() string {
 	boneNames := r.ItemBoneNames()
 
@@ -58,6 +129,15 @@ func (r *OutputRecord) ItemBoneNames() []string {
 	return names
 }
 
+// OutputReduceReport ボーン毎の間引き結果
+type OutputReduceReport struct {
+	BoneName         string  `json:"bone_name"`          // ボーン名
+	FrameCount       int     `json:"frame_count"`        // 間引き前キーフレーム数
+	KeyCount         int     `json:"key_count"`          // 間引き後キーフレーム数
+	MaxPositionError float64 `json:"max_position_error"` // 最大位置誤差
+	MaxRotationError float64 `json:"max_rotation_error"` // 最大回転誤差（度）
+}
+
 type OutputTree struct {
 	Items []*OutputItem
 }
@@ -116,9 +196,10 @@ func (r OutputTree) AtByBoneIndex(boneIndex int) *OutputItem {
 }
 
 type OutputItem struct {
-	Bone     *pmx.Bone     // ボーン情報
+	Bone     *pmx.Bone     `json:"-"`         // ボーン情報
+	BoneName string        `json:"bone_name"` // ボーン名
 	Checked  bool          // チェック有無
-	Parent   *OutputItem   `json:"parent"`   // 親ボーンアイテム
+	Parent   *OutputItem   `json:"-"`        // 親ボーンアイテム
 	Children []*OutputItem `json:"Children"` // 子ボーンアイテム
 }
 
@@ -130,6 +211,10 @@ func newOutputItem(bone *pmx.Bone, parent *OutputItem) *OutputItem {
 		Children: []*OutputItem{},
 	}
 
+	if bone != nil {
+		item.BoneName = bone.Name()
+	}
+
 	return item
 }
 
@@ -147,6 +232,28 @@ func (oi *OutputItem) ItemBoneNames() []string {
 	return names
 }
 
+// checkedBoneNames 表示枠の有無に関わらず、チェックされているボーン名一覧を取得
+func (oi *OutputItem) checkedBoneNames() []string {
+	names := make([]string, 0)
+	if oi.Checked && oi.BoneName != "" {
+		names = append(names, oi.BoneName)
+	}
+
+	for _, child := range oi.Children {
+		names = append(names, child.checkedBoneNames()...)
+	}
+
+	return names
+}
+
+func (oi *OutputItem) setCheckedByBoneNames(boneNames []string) {
+	oi.Checked = slices.Contains(boneNames, oi.BoneName)
+
+	for _, child := range oi.Children {
+		child.setCheckedByBoneNames(boneNames)
+	}
+}
+
 func (oi *OutputItem) AtByBoneIndex(boneIndex int) *OutputItem {
 	if oi.Bone == nil {
 		return nil
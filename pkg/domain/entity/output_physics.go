@@ -0,0 +1,24 @@
+package entity
+
+type OutputPhysicsType = int
+
+const (
+	OutputPhysicsTypeNone   OutputPhysicsType = 0 // 元モデルの物理のまま保存
+	OutputPhysicsTypeRecord OutputPhysicsType = 1 // 指定したモデル物理設定の最大値を書き込む
+	OutputPhysicsTypeFrame  OutputPhysicsType = 2 // 指定フレーム時点のモデル物理設定の値を書き込む
+)
+
+// OutputPhysics 出力モデルへのモデル物理設定の書き込み方法
+type OutputPhysics struct {
+	Type        OutputPhysicsType `json:"type"`         // 書き込み方法
+	RecordIndex int               `json:"record_index"` // 書き込むモデル物理設定のINDEX（設定指定の場合）
+	Frame       float32           `json:"frame"`        // 書き込む時点のフレーム（フレーム指定の場合）
+}
+
+func NewOutputPhysics() *OutputPhysics {
+	return &OutputPhysics{
+		Type:        OutputPhysicsTypeNone,
+		RecordIndex: 0,
+		Frame:       0,
+	}
+}
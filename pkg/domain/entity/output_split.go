@@ -0,0 +1,27 @@
+package entity
+
+type OutputSplitType = int
+
+const (
+	OutputSplitTypeMaxFrames OutputSplitType = 0 // 最大キーフレーム数を超えたら分割
+	OutputSplitTypeInterval  OutputSplitType = 1 // 一定フレーム間隔で分割
+	OutputSplitTypeFrames    OutputSplitType = 2 // 指定フレームで分割
+	OutputSplitTypeRecord    OutputSplitType = 3 // 出力設定の区間毎に分割
+	OutputSplitTypeBoneGroup OutputSplitType = 4 // ボーングループ（物理・IK・その他）毎に分割
+)
+
+// OutputSplit 出力モーションの分割方法
+// いずれの分割方法でも、最大キーフレーム数を超える場合は追加で分割する
+type OutputSplit struct {
+	Type     OutputSplitType `json:"type"`     // 分割方法
+	Interval float64         `json:"interval"` // 分割間隔（一定フレーム間隔の場合）
+	Frames   []float32       `json:"frames"`   // 分割フレーム（指定フレームの場合）
+}
+
+func NewOutputSplit() *OutputSplit {
+	return &OutputSplit{
+		Type:     OutputSplitTypeMaxFrames,
+		Interval: 1000,
+		Frames:   make([]float32, 0),
+	}
+}
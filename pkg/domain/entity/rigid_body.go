@@ -13,6 +13,7 @@ type RigidBodyRecord struct {
 	EndFrame      float32        `json:"end_frame"`       // 区間終了フレーム
 	MaxStartFrame float32        `json:"max_start_frame"` // 最大値開始フレーム
 	MaxEndFrame   float32        `json:"max_end_frame"`   // 最大値終了フレーム
+	Easing        *Easing        `json:"easing"`          // 変化区間の補間方法
 	Tree          *RigidBodyTree `json:"items"`           // 剛体アイテム一覧
 }
 
@@ -22,10 +23,47 @@ func NewRigidBodyRecord(startFrame, endFrame float32, model *pmx.PmxModel) *Rigi
 		MaxStartFrame: startFrame,
 		MaxEndFrame:   endFrame,
 		EndFrame:      endFrame,
+		Easing:        NewEasing(EasingTypeLinear),
 		Tree:          newRigidBodyTree(model),
 	}
 }
 
+// RatioAt 指定フレームでの変更前(0)から最大値(1)までの割合
+//   - 区間外は0、最大値区間は1、変化区間は補間方法に沿った割合
+func (r *RigidBodyRecord) RatioAt(f float32) float64 {
+	switch {
+	case f < r.StartFrame || f > r.EndFrame:
+		return 0
+	case f >= r.MaxStartFrame && f <= r.MaxEndFrame:
+		return 1
+	case f < r.MaxStartFrame:
+		t := float64(f-r.StartFrame) / float64(r.MaxStartFrame-r.StartFrame)
+		return r.Easing.Ratio(t)
+	default:
+		t := float64(f-r.MaxEndFrame) / float64(r.EndFrame-r.MaxEndFrame)
+		return 1 - r.Easing.Ratio(t)
+	}
+}
+
+// Restore 設定ファイルから読み込んだモデル物理設定をモデルの剛体に紐付け直す
+func (r *RigidBodyRecord) Restore(model *pmx.PmxModel) {
+	if model == nil {
+		return
+	}
+
+	savedItems := make(map[string]*RigidBodyItem)
+	if r.Tree != nil {
+		for _, item := range r.Tree.Items {
+			item.collectModifiedItems(savedItems)
+		}
+	}
+
+	r.Tree = newRigidBodyTree(model)
+	for _, item := range r.Tree.Items {
+		item.restoreModifiedItems(savedItems)
+	}
+}
+
 func (r *RigidBodyRecord) ItemNames() string {
 	var names []string
 	for _, item := range r.Tree.Items {
@@ -138,8 +176,8 @@ func (pi *RigidBodyTree) AtByRigidBodyIndex(rigidBodyIndex int) *RigidBodyItem {
 }
 
 type RigidBodyItem struct {
-	Bone           *pmx.Bone        // 剛体に紐付くボーン情報
-	RigidBody      *pmx.RigidBody   // 剛体情報
+	Bone           *pmx.Bone        `json:"-"`                // 剛体に紐付くボーン情報
+	RigidBody      *pmx.RigidBody   `json:"-"`                // 剛体情報
 	SizeRatio      *mmath.MVec3     `json:"size_ratio"`       // 大きさ比率
 	Position       *mmath.MVec3     `json:"position"`         // 位置
 	MassRatio      float64          `json:"mass_ratio"`       // 質量比率
@@ -148,7 +186,7 @@ type RigidBodyItem struct {
 	Modified       bool             `json:"modified"`         // 変更されたかどうか
 	RigidBodyIndex int              `json:"rigid_body_index"` // 剛体インデックス
 	RigidBodyName  string           `json:"rigid_body_name"`  // 剛体名
-	Parent         *RigidBodyItem   `json:"parent"`           // 親剛体アイテム
+	Parent         *RigidBodyItem   `json:"-"`                // 親剛体アイテム
 	Children       []*RigidBodyItem `json:"children"`         // 子剛体アイテム
 }
 
@@ -186,6 +224,31 @@ func (pi *RigidBodyItem) ItemNames(names []string) []string {
 	return names
 }
 
+func (pi *RigidBodyItem) collectModifiedItems(items map[string]*RigidBodyItem) {
+	if pi.Modified && pi.RigidBodyName != "" {
+		items[pi.RigidBodyName] = pi
+	}
+
+	for _, child := range pi.Children {
+		child.collectModifiedItems(items)
+	}
+}
+
+func (pi *RigidBodyItem) restoreModifiedItems(items map[string]*RigidBodyItem) {
+	if saved, ok := items[pi.RigidBodyName]; ok && pi.RigidBody != nil {
+		pi.SizeRatio = saved.SizeRatio
+		pi.Position = saved.Position
+		pi.MassRatio = saved.MassRatio
+		pi.StiffnessRatio = saved.StiffnessRatio
+		pi.TensionRatio = saved.TensionRatio
+		pi.Modified = true
+	}
+
+	for _, child := range pi.Children {
+		child.restoreModifiedItems(items)
+	}
+}
+
 func (pi *RigidBodyItem) AtByBoneIndex(boneIndex int) *RigidBodyItem {
 	if pi.Bone == nil {
 		return nil
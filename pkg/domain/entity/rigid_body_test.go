@@ -0,0 +1,116 @@
+package entity
+
+import (
+	"math"
+	"testing"
+
+	"github.com/miu200521358/bone_baker/pkg/testutil"
+	"github.com/miu200521358/mlib_go/pkg/domain/pmx"
+)
+
+func TestNewRigidBodyTree(t *testing.T) {
+	model := testutil.NewModel()
+	tree := newRigidBodyTree(model)
+
+	tests := []struct {
+		name          string
+		rigidBodyName string
+		wantBoneName  string
+		wantParent    string // 親アイテムのボーン名（ルートの場合は空）
+	}{
+		{name: "表示枠ありのボーンの剛体", rigidBodyName: testutil.RigidBodyHair1, wantBoneName: testutil.BoneHair1, wantParent: testutil.BoneUpperBody},
+		{name: "表示枠なしのボーンの剛体", rigidBodyName: testutil.RigidBodyHair2, wantBoneName: testutil.BoneHair2, wantParent: testutil.BoneHair1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rigidBody, err := model.RigidBodies.GetByName(tt.rigidBodyName)
+			if err != nil {
+				t.Fatalf("rigid body %s not found: %v", tt.rigidBodyName, err)
+			}
+
+			item := tree.AtByRigidBodyIndex(rigidBody.Index())
+			if item == nil {
+				t.Fatalf("item for %s not found", tt.rigidBodyName)
+			}
+
+			if item.RigidBodyName != tt.rigidBodyName {
+				t.Errorf("RigidBodyName = %s, want %s", item.RigidBodyName, tt.rigidBodyName)
+			}
+			if item.Bone == nil || item.Bone.Name() != tt.wantBoneName {
+				t.Errorf("Bone = %v, want %s", item.Bone, tt.wantBoneName)
+			}
+			if item.Parent == nil || item.Parent.Bone.Name() != tt.wantParent {
+				t.Errorf("Parent = %v, want %s", item.Parent, tt.wantParent)
+			}
+			if item.Modified || item.MassRatio != 1 || item.StiffnessRatio != 1 || item.TensionRatio != 1 {
+				t.Errorf("item is not initialized: %+v", item)
+			}
+		})
+	}
+
+	// 剛体を持つボーンに至るツリーだけが残る
+	if len(tree.Items) != 1 || tree.Items[0].Bone.Name() != testutil.BoneCenter {
+		t.Fatalf("root items = %v, want only %s", tree.Items, testutil.BoneCenter)
+	}
+}
+
+func TestNewRigidBodyTree_WithoutRigidBodies(t *testing.T) {
+	model := pmx.NewPmxModel("")
+	bone := pmx.NewBone()
+	bone.SetName(testutil.BoneCenter)
+	bone.ParentIndex = -1
+	model.Bones.Append(bone)
+	model.Bones.Setup()
+
+	tree := newRigidBodyTree(model)
+	if len(tree.Items) != 0 {
+		t.Errorf("len(Items) = %d, want 0", len(tree.Items))
+	}
+}
+
+func TestRigidBodyRecord_Restore(t *testing.T) {
+	model := testutil.NewModel()
+	record := NewRigidBodyRecord(0, 10, model)
+
+	rigidBody, _ := model.RigidBodies.GetByName(testutil.RigidBodyHair2)
+	item := record.Tree.AtByRigidBodyIndex(rigidBody.Index())
+	item.MassRatio = 2.5
+	item.Modified = true
+
+	// 設定ファイルから読み込んだ直後を想定して、別モデルに紐付け直す
+	record.Restore(testutil.NewModel())
+
+	restored := record.Tree.AtByRigidBodyIndex(rigidBody.Index())
+	if restored == nil || !restored.Modified || restored.MassRatio != 2.5 {
+		t.Errorf("restored item = %+v, want modified with mass ratio 2.5", restored)
+	}
+}
+
+func TestRigidBodyRecord_RatioAt(t *testing.T) {
+	tests := []struct {
+		name   string
+		easing *Easing
+		frame  float32
+		want   float64
+	}{
+		{name: "区間前", easing: NewEasing(EasingTypeLinear), frame: 9, want: 0},
+		{name: "区間開始", easing: NewEasing(EasingTypeLinear), frame: 10, want: 0},
+		{name: "変化区間の中間", easing: NewEasing(EasingTypeLinear), frame: 15, want: 0.5},
+		{name: "最大値区間", easing: NewEasing(EasingTypeLinear), frame: 25, want: 1},
+		{name: "戻り区間の中間", easing: NewEasing(EasingTypeLinear), frame: 35, want: 0.5},
+		{name: "区間終了", easing: NewEasing(EasingTypeLinear), frame: 40, want: 0},
+		{name: "区間後", easing: NewEasing(EasingTypeLinear), frame: 41, want: 0},
+		{name: "ステップは最大値まで変化しない", easing: NewEasing(EasingTypeStep), frame: 19, want: 0},
+		{name: "補間方法未設定は線形", easing: nil, frame: 15, want: 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := &RigidBodyRecord{StartFrame: 10, MaxStartFrame: 20, MaxEndFrame: 30, EndFrame: 40, Easing: tt.easing}
+			if got := record.RatioAt(tt.frame); math.Abs(got-tt.want) > 1e-6 {
+				t.Errorf("RatioAt(%v) = %f, want %f", tt.frame, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package entity
+
+import "github.com/miu200521358/mlib_go/pkg/config/mi18n"
+
+type ValidationSeverity = int
+
+const (
+	ValidationSeverityWarning ValidationSeverity = 1 // 焼き込みは可能だが、結果に影響する
+	ValidationSeverityError   ValidationSeverity = 2 // 物理演算・出力が正しく行えない
+)
+
+// 検出内容の種別（メッセージの翻訳キーを兼ねる。対処方法は「<種別>対処」をキーとする）
+const (
+	ValidationCodeRigidBodyMass     = "剛体質量不正"
+	ValidationCodeRigidBodyNoBone   = "剛体ボーン未設定"
+	ValidationCodeJointMissingBody  = "ジョイント剛体未設定"
+	ValidationCodeJointSameBody     = "ジョイント剛体重複"
+	ValidationCodeBoneNoDisplaySlot = "物理ボーン表示枠なし"
+	ValidationCodeBoneNameTooLong   = "ボーン名バイト数超過"
+)
+
+// ValidationIssue 焼き込み前のモデル検査で見つかった問題
+type ValidationIssue struct {
+	Severity ValidationSeverity `json:"severity"` // 重要度
+	Code     string             `json:"code"`     // 種別
+	Target   string             `json:"target"`   // 対象（剛体名・ジョイント名・ボーン名）
+	Params   map[string]any     `json:"params"`   // メッセージの埋め込み値
+}
+
+func NewValidationIssue(severity ValidationSeverity, code, target string, params map[string]any) *ValidationIssue {
+	if params == nil {
+		params = make(map[string]any)
+	}
+	params["Target"] = target
+
+	return &ValidationIssue{
+		Severity: severity,
+		Code:     code,
+		Target:   target,
+		Params:   params,
+	}
+}
+
+// Message 問題の内容
+func (i *ValidationIssue) Message() string {
+	return mi18n.T(i.Code, i.Params)
+}
+
+// Suggestion 対処方法
+func (i *ValidationIssue) Suggestion() string {
+	return mi18n.T(i.Code+"対処", i.Params)
+}
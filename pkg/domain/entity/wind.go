@@ -5,6 +5,13 @@ import (
 	"github.com/miu200521358/mlib_go/pkg/domain/physics"
 )
 
+type WindScope = int
+
+const (
+	WindScopeGlobal WindScope = 0 // 全セット共通の風を適用
+	WindScopeSet    WindScope = 1 // セット個別の風を適用
+)
+
 // 風用物理定義
 type WindRecord struct {
 	StartFrame float32             `json:"start_frame"` // 区間開始フレーム
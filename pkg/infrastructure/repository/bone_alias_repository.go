@@ -0,0 +1,36 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/miu200521358/bone_baker/pkg/domain/entity"
+	"github.com/miu200521358/mlib_go/pkg/config/mi18n"
+	"github.com/miu200521358/mlib_go/pkg/config/mlog"
+)
+
+type BoneAliasRepository struct{}
+
+// NewBoneAliasRepository コンストラクタ
+func NewBoneAliasRepository() *BoneAliasRepository {
+	return &BoneAliasRepository{}
+}
+
+// Load ユーザー定義のボーン別名ファイルを読み込み
+//   - 形式: {"groups": [["グルーブ", "グルーヴ", "groove"], ...]}
+func (r *BoneAliasRepository) Load(filePath string) (*entity.BoneAliasTable, error) {
+	input, err := os.ReadFile(filePath)
+	if err != nil {
+		mlog.E(mi18n.T("ボーン別名ファイル読込失敗エラー"), err, "")
+		return nil, err
+	}
+
+	var table entity.BoneAliasTable
+	if err := json.Unmarshal(input, &table); err != nil {
+		mlog.E(mi18n.T("ボーン別名ファイル読込失敗エラー"), err, "")
+		return nil, err
+	}
+
+	mlog.I(mi18n.T("ボーン別名ファイル読込成功", map[string]any{"Path": filePath}))
+	return &table, nil
+}
@@ -0,0 +1,502 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/miu200521358/mlib_go/pkg/config/mi18n"
+	"github.com/miu200521358/mlib_go/pkg/domain/pmx"
+)
+
+// 設定ファイルの現行スキーマバージョン
+// entity の構造体にJSON項目を追加・変更した場合は、バージョンを上げて migrations に変換処理を追加すること
+// 変換処理で補う値は、entity の既定値が後から変わっても旧ファイルの意味が変わらないよう、その時点の値を直接書くこと
+const currentSchemaVersion = 16
+
+// migration 1世代前のスキーマを1世代分だけ引き上げる変換処理
+type migration func(data map[string]any) error
+
+// migrations 変換前バージョンをキーとした変換処理一覧
+var migrations = map[int]migration{
+	0:  migrateV0ToV1,
+	1:  migrateV1ToV2,
+	2:  migrateV2ToV3,
+	3:  migrateV3ToV4,
+	4:  migrateV4ToV5,
+	5:  migrateV5ToV6,
+	6:  migrateV6ToV7,
+	7:  migrateV7ToV8,
+	8:  migrateV8ToV9,
+	9:  migrateV9ToV10,
+	10: migrateV10ToV11,
+	11: migrateV11ToV12,
+	12: migrateV12ToV13,
+	13: migrateV13ToV14,
+	14: migrateV14ToV15,
+	15: migrateV15ToV16,
+}
+
+// migrate 読み込んだJSONを現行スキーマまで段階的に変換する
+func migrate(input []byte) ([]byte, error) {
+	var data map[string]any
+	if err := json.Unmarshal(input, &data); err != nil {
+		return nil, err
+	}
+
+	version := schemaVersion(data)
+	if version > currentSchemaVersion {
+		return nil, errors.New(mi18n.T("物理焼き込みセットバージョンエラー", map[string]any{
+			"Version": version, "Supported": currentSchemaVersion}))
+	}
+
+	for v := version; v < currentSchemaVersion; v++ {
+		m, ok := migrations[v]
+		if !ok {
+			return nil, errors.New(mi18n.T("物理焼き込みセット変換エラー", map[string]any{"Version": v}))
+		}
+		if err := m(data); err != nil {
+			return nil, err
+		}
+		data["version"] = v + 1
+	}
+
+	return json.Marshal(data)
+}
+
+// schemaVersion JSONに記録されたスキーマバージョンを取得する（未記録の場合は0）
+func schemaVersion(data map[string]any) int {
+	if v, ok := data["version"].(float64); ok {
+		return int(v)
+	}
+	return 0
+}
+
+// migrateV0ToV1 バージョン未記録の設定ファイルを変換する
+//   - 風設定レコードが無い場合は空で追加
+//   - モデル物理設定レコードに最大値区間が無い場合は、区間全体を最大値区間とする
+func migrateV0ToV1(data map[string]any) error {
+	if _, ok := data["wind_records"]; !ok {
+		data["wind_records"] = []any{}
+	}
+
+	bakeSets, _ := data["bake_sets"].([]any)
+	for _, bs := range bakeSets {
+		bakeSet, ok := bs.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		records, _ := bakeSet["rigid_body_records"].([]any)
+		for _, r := range records {
+			record, ok := r.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			if _, ok := record["max_start_frame"]; !ok {
+				record["max_start_frame"] = record["start_frame"]
+			}
+			if _, ok := record["max_end_frame"]; !ok {
+				record["max_end_frame"] = record["end_frame"]
+			}
+		}
+	}
+
+	return nil
+}
+
+// migrateV1ToV2 セット個別の風設定を追加する
+//   - 既存のセットは全セット共通の風設定を使用する
+func migrateV1ToV2(data map[string]any) error {
+	bakeSets, _ := data["bake_sets"].([]any)
+	for _, bs := range bakeSets {
+		bakeSet, ok := bs.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		if _, ok := bakeSet["wind_scope"]; !ok {
+			bakeSet["wind_scope"] = 0
+		}
+		if _, ok := bakeSet["wind_records"]; !ok {
+			bakeSet["wind_records"] = []any{}
+		}
+	}
+
+	return nil
+}
+
+// migrateV2ToV3 モデル物理設定レコードに変化区間の補間方法を追加する
+//   - 既存のレコードは線形補間とする
+func migrateV2ToV3(data map[string]any) error {
+	bakeSets, _ := data["bake_sets"].([]any)
+	for _, bs := range bakeSets {
+		bakeSet, ok := bs.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		records, _ := bakeSet["rigid_body_records"].([]any)
+		for _, r := range records {
+			record, ok := r.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			if _, ok := record["easing"]; !ok {
+				record["easing"] = map[string]any{
+					"type": 0, "x1": 20, "y1": 20, "x2": 107, "y2": 107,
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// migrateV3ToV4 出力設定レコードに間引きの許容誤差を追加する
+//   - 既存のレコードは初期値とする
+func migrateV3ToV4(data map[string]any) error {
+	bakeSets, _ := data["bake_sets"].([]any)
+	for _, bs := range bakeSets {
+		bakeSet, ok := bs.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		records, _ := bakeSet["output_records"].([]any)
+		for _, r := range records {
+			record, ok := r.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			if _, ok := record["reduce_position_tolerance"]; !ok {
+				record["reduce_position_tolerance"] = 0.05
+			}
+			if _, ok := record["reduce_rotation_tolerance"]; !ok {
+				record["reduce_rotation_tolerance"] = 0.5
+			}
+			if _, ok := record["reduce_max_interval"]; !ok {
+				record["reduce_max_interval"] = 0
+			}
+		}
+	}
+
+	return nil
+}
+
+// migrateV4ToV5 焼き込みセットに出力モーションの分割方法を追加する
+//   - 既存のセットは最大キーフレーム数での分割とする
+func migrateV4ToV5(data map[string]any) error {
+	bakeSets, _ := data["bake_sets"].([]any)
+	for _, bs := range bakeSets {
+		bakeSet, ok := bs.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		if _, ok := bakeSet["output_split"]; !ok {
+			bakeSet["output_split"] = map[string]any{
+				"type":     0, // 最大キーフレーム数を超えたら分割
+				"interval": 1000,
+				"frames":   []any{},
+			}
+		}
+	}
+
+	return nil
+}
+
+// migrateV5ToV6 焼き込みセットに衝突用剛体の設定を追加する
+//   - 既存のセットは従来の固定の衝突用剛体とする
+func migrateV5ToV6(data map[string]any) error {
+	bakeSets, _ := data["bake_sets"].([]any)
+	for _, bs := range bakeSets {
+		bakeSet, ok := bs.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		if _, ok := bakeSet["collision_proxy_profile"]; !ok {
+			bakeSet["collision_proxy_profile"] = legacyCollisionProxyProfile()
+		}
+	}
+
+	return nil
+}
+
+// legacyCollisionProxyProfile バージョン6で追加した時点の衝突用剛体の初期設定
+//   - ひざ・足首・つま先・かかと・ひじ・手首・目の左右に、床剛体と同じグループの球を追加する
+func legacyCollisionProxyProfile() map[string]any {
+	items := make([]any, 0)
+	for _, boneName := range []pmx.StandardBoneName{pmx.KNEE, pmx.ANKLE, pmx.TOE_T, pmx.HEEL, pmx.ELBOW, pmx.WRIST, pmx.EYE} {
+		for _, direction := range []pmx.BoneDirection{pmx.BONE_DIRECTION_LEFT, pmx.BONE_DIRECTION_RIGHT} {
+			items = append(items, map[string]any{
+				"bone_name":            boneName.StringFromDirection(direction),
+				"shape":                0, // 球
+				"size_type":            0, // ウェイト頂点から算出
+				"size_ratio":           0.3,
+				"size":                 map[string]any{"X": 0.2, "Y": 0.2, "Z": 0.2},
+				"collision_group":      15,
+				"collision_group_mask": []any{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+			})
+		}
+	}
+
+	return map[string]any{"items": items}
+}
+
+// migrateV6ToV7 焼き込みセットに物理ボーンの名前対応表（焼き込み用の名前 → 元の名前）を追加する
+//   - 対応表はモデル読み込み時に作り直すため、空で追加する
+func migrateV6ToV7(data map[string]any) error {
+	bakeSets, _ := data["bake_sets"].([]any)
+	for _, bs := range bakeSets {
+		bakeSet, ok := bs.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		if _, ok := bakeSet["bone_name_map"]; !ok {
+			bakeSet["bone_name_map"] = map[string]any{}
+		}
+	}
+
+	return nil
+}
+
+// migrateV7ToV8 焼き込みセットに出力モーションのボーン名種別を追加する
+//   - 既存のセットは従来通り焼き込み用のボーン名で出力する
+func migrateV7ToV8(data map[string]any) error {
+	bakeSets, _ := data["bake_sets"].([]any)
+	for _, bs := range bakeSets {
+		bakeSet, ok := bs.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		if _, ok := bakeSet["output_bone_name_type"]; !ok {
+			bakeSet["output_bone_name_type"] = 0 // 焼き込み用に改名したボーン名
+		}
+	}
+
+	return nil
+}
+
+// migrateV8ToV9 焼き込みセットに出力モデルの保存方法を追加する
+//   - 既存のセットは従来通りシステム用の追加を含めて保存する
+func migrateV8ToV9(data map[string]any) error {
+	bakeSets, _ := data["bake_sets"].([]any)
+	for _, bs := range bakeSets {
+		bakeSet, ok := bs.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		if _, ok := bakeSet["output_clean_model"]; !ok {
+			bakeSet["output_clean_model"] = false
+		}
+	}
+
+	return nil
+}
+
+// migrateV9ToV10 焼き込みセットに出力モデルへのモデル物理設定の書き込み方法を追加する
+//   - 既存のセットは従来通り元モデルの物理のまま保存する
+func migrateV9ToV10(data map[string]any) error {
+	bakeSets, _ := data["bake_sets"].([]any)
+	for _, bs := range bakeSets {
+		bakeSet, ok := bs.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		if _, ok := bakeSet["output_physics"]; !ok {
+			bakeSet["output_physics"] = map[string]any{
+				"type":         0, // 元モデルの物理のまま保存
+				"record_index": 0,
+				"frame":        0,
+			}
+		}
+	}
+
+	return nil
+}
+
+// migrateV10ToV11 焼き込みセットにユーザー定義のボーン別名ファイルパスを追加する
+//   - 既存のセットは標準の別名表のみを使う
+func migrateV10ToV11(data map[string]any) error {
+	bakeSets, _ := data["bake_sets"].([]any)
+	for _, bs := range bakeSets {
+		bakeSet, ok := bs.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		if _, ok := bakeSet["bone_alias_paths"]; !ok {
+			bakeSet["bone_alias_paths"] = []any{}
+		}
+	}
+
+	return nil
+}
+
+// migrateV11ToV12 焼き込みセットに元モーションに重ねるモーションと重ね方を追加する
+//   - 既存のセットは元モーション1ファイルのみとする
+func migrateV11ToV12(data map[string]any) error {
+	bakeSets, _ := data["bake_sets"].([]any)
+	for _, bs := range bakeSets {
+		bakeSet, ok := bs.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		if _, ok := bakeSet["merge_motion_paths"]; !ok {
+			bakeSet["merge_motion_paths"] = []any{}
+		}
+		if _, ok := bakeSet["motion_merge_type"]; !ok {
+			bakeSet["motion_merge_type"] = 0 // 後のファイルのキーフレームで置き換え
+		}
+	}
+
+	return nil
+}
+
+// migrateV12ToV13 出力設定レコードに加算焼き込みの有無と割合を追加する
+//   - 既存のレコードは従来通り焼き込み結果で上書きする
+func migrateV12ToV13(data map[string]any) error {
+	bakeSets, _ := data["bake_sets"].([]any)
+	for _, bs := range bakeSets {
+		bakeSet, ok := bs.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		records, _ := bakeSet["output_records"].([]any)
+		for _, r := range records {
+			record, ok := r.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			if _, ok := record["additive"]; !ok {
+				record["additive"] = false
+			}
+			if _, ok := record["additive_weight"]; !ok {
+				record["additive_weight"] = 1.0
+			}
+		}
+	}
+
+	return nil
+}
+
+// migrateV13ToV14 出力設定レコードにブレンドイン・ブレンドアウトのフレーム数を追加する
+//   - 既存のレコードは従来通り区間の境界で切り替える
+func migrateV13ToV14(data map[string]any) error {
+	bakeSets, _ := data["bake_sets"].([]any)
+	for _, bs := range bakeSets {
+		bakeSet, ok := bs.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		records, _ := bakeSet["output_records"].([]any)
+		for _, r := range records {
+			record, ok := r.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			if _, ok := record["blend_in_frames"]; !ok {
+				record["blend_in_frames"] = 0
+			}
+			if _, ok := record["blend_out_frames"]; !ok {
+				record["blend_out_frames"] = 0
+			}
+		}
+	}
+
+	return nil
+}
+
+// migrateV14ToV15 出力設定レコードに間引き方法を追加する
+//   - 既存のレコードは従来通り線形補間で間引く
+func migrateV14ToV15(data map[string]any) error {
+	bakeSets, _ := data["bake_sets"].([]any)
+	for _, bs := range bakeSets {
+		bakeSet, ok := bs.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		records, _ := bakeSet["output_records"].([]any)
+		for _, r := range records {
+			record, ok := r.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			if _, ok := record["reduce_type"]; !ok {
+				record["reduce_type"] = 0 // 線形補間
+			}
+		}
+	}
+
+	return nil
+}
+
+// migrateV15ToV16 出力設定のボーンアイテムにボーン名を追加する
+//   - 旧形式はボーン情報そのもの（Bone）と親アイテム（parent）を保存していたため、ボーン情報の名前から補う
+//   - 名前が取れないアイテムはチェック無しとして読み込まれる
+func migrateV15ToV16(data map[string]any) error {
+	bakeSets, _ := data["bake_sets"].([]any)
+	for _, bs := range bakeSets {
+		bakeSet, ok := bs.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		records, _ := bakeSet["output_records"].([]any)
+		for _, r := range records {
+			record, ok := r.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			tree, _ := record["items"].(map[string]any)
+			items, _ := tree["Items"].([]any)
+			for _, item := range items {
+				migrateOutputItemBoneName(item)
+			}
+		}
+	}
+
+	return nil
+}
+
+// migrateOutputItemBoneName ボーンアイテムとその子アイテムに、旧形式のボーン情報からボーン名を補う
+func migrateOutputItemBoneName(value any) {
+	item, ok := value.(map[string]any)
+	if !ok {
+		return
+	}
+
+	if _, ok := item["bone_name"]; !ok {
+		if bone, ok := item["Bone"].(map[string]any); ok {
+			for _, key := range []string{"name", "Name"} {
+				if name, ok := bone[key].(string); ok {
+					item["bone_name"] = name
+					break
+				}
+			}
+		}
+	}
+	delete(item, "Bone")
+	delete(item, "parent")
+
+	children, _ := item["Children"].([]any)
+	for _, child := range children {
+		migrateOutputItemBoneName(child)
+	}
+}
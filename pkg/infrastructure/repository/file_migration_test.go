@@ -0,0 +1,57 @@
+package infrastructure
+
+import "testing"
+
+func TestMigrateV15ToV16(t *testing.T) {
+	data := map[string]any{
+		"bake_sets": []any{
+			map[string]any{
+				"output_records": []any{
+					map[string]any{
+						"items": map[string]any{
+							"Items": []any{
+								map[string]any{
+									"Bone":    map[string]any{"name": "センター"},
+									"Checked": false,
+									"Children": []any{
+										map[string]any{
+											"Bone":     map[string]any{"name": "髪1"},
+											"Checked":  true,
+											"parent":   map[string]any{},
+											"Children": []any{},
+										},
+									},
+								},
+								map[string]any{"bone_name": "上半身", "Checked": true},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if err := migrateV15ToV16(data); err != nil {
+		t.Fatal(err)
+	}
+
+	record := data["bake_sets"].([]any)[0].(map[string]any)["output_records"].([]any)[0].(map[string]any)
+	items := record["items"].(map[string]any)["Items"].([]any)
+	root := items[0].(map[string]any)
+	child := root["Children"].([]any)[0].(map[string]any)
+
+	for _, tt := range []struct {
+		item map[string]any
+		want string
+	}{{root, "センター"}, {child, "髪1"}, {items[1].(map[string]any), "上半身"}} {
+		if got := tt.item["bone_name"]; got != tt.want {
+			t.Errorf("bone_name = %v, want %s", got, tt.want)
+		}
+		if _, ok := tt.item["Bone"]; ok {
+			t.Errorf("Bone was not removed: %v", tt.item)
+		}
+	}
+	if _, ok := child["parent"]; ok {
+		t.Errorf("parent was not removed: %v", child)
+	}
+}
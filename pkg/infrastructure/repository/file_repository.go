@@ -19,12 +19,19 @@ func NewFileRepository() *FileRepository {
 }
 
 type jsonData struct {
+	Version        int                     `json:"version"`
 	BakeSets       []*entity.BakeSet       `json:"bake_sets"`
 	PhysicsRecords []*entity.PhysicsRecord `json:"physics_records"`
+	WindRecords    []*entity.WindRecord    `json:"wind_records"`
 }
 
 // Save BakeSetのリストをJSONファイルに保存
-func (r *FileRepository) Save(bakeSets []*entity.BakeSet, physicsRecords []*entity.PhysicsRecord, filePath string) error {
+func (r *FileRepository) Save(
+	bakeSets []*entity.BakeSet,
+	physicsRecords []*entity.PhysicsRecord,
+	windRecords []*entity.WindRecord,
+	filePath string,
+) error {
 	// ファイル拡張子の確認
 	if strings.ToLower(filepath.Ext(filePath)) != ".json" {
 		filePath += ".json"
@@ -32,8 +39,10 @@ func (r *FileRepository) Save(bakeSets []*entity.BakeSet, physicsRecords []*enti
 
 	// JSONにシリアライズ
 	output, err := json.Marshal(jsonData{
+		Version:        currentSchemaVersion,
 		BakeSets:       bakeSets,
 		PhysicsRecords: physicsRecords,
+		WindRecords:    windRecords,
 	})
 	if err != nil {
 		mlog.E(mi18n.T("物理焼き込みセット保存失敗エラー"), err, "")
@@ -51,12 +60,24 @@ func (r *FileRepository) Save(bakeSets []*entity.BakeSet, physicsRecords []*enti
 }
 
 // Load JSONファイルからBakeSetのリストを読み込み
-func (r *FileRepository) Load(filePath string) (bakeSets []*entity.BakeSet, physicsRecords []*entity.PhysicsRecord, err error) {
+func (r *FileRepository) Load(filePath string) (
+	bakeSets []*entity.BakeSet,
+	physicsRecords []*entity.PhysicsRecord,
+	windRecords []*entity.WindRecord,
+	err error,
+) {
 	// ファイル読み込み
 	input, err := os.ReadFile(filePath)
 	if err != nil {
 		mlog.E(mi18n.T("物理焼き込みセット読込失敗エラー"), err, "")
-		return nil, nil, err
+		return nil, nil, nil, err
+	}
+
+	// 旧バージョンの設定を現行スキーマに変換
+	input, err = migrate(input)
+	if err != nil {
+		mlog.E(mi18n.T("物理焼き込みセット読込失敗エラー"), err, "")
+		return nil, nil, nil, err
 	}
 
 	// JSONから逆シリアライズ
@@ -64,9 +85,20 @@ func (r *FileRepository) Load(filePath string) (bakeSets []*entity.BakeSet, phys
 
 	if err := json.Unmarshal(input, &data); err != nil {
 		mlog.E(mi18n.T("物理焼き込みセット読込失敗エラー"), err, "")
-		return nil, nil, err
+		return nil, nil, nil, err
+	}
+
+	// 剛体に直接設定する値は、読み込み時に範囲を確認する
+	for _, bakeSet := range data.BakeSets {
+		if bakeSet.CollisionProxyProfile == nil {
+			continue
+		}
+		if err := bakeSet.CollisionProxyProfile.Validate(); err != nil {
+			mlog.E(mi18n.T("物理焼き込みセット読込失敗エラー"), err, "")
+			return nil, nil, nil, err
+		}
 	}
 
 	mlog.I(mi18n.T("物理焼き込みセット読込成功", map[string]any{"Path": filePath}))
-	return data.BakeSets, data.PhysicsRecords, nil
+	return data.BakeSets, data.PhysicsRecords, data.WindRecords, nil
 }
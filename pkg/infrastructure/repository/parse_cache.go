@@ -0,0 +1,167 @@
+package infrastructure
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/miu200521358/mlib_go/pkg/domain/pmx"
+	"github.com/miu200521358/mlib_go/pkg/domain/vmd"
+	"github.com/miu200521358/mlib_go/pkg/infrastructure/repository"
+)
+
+// 解析結果を保持する最大件数（モデル・モーションそれぞれ）
+const parseCacheLimit = 4
+
+// 解析結果を保持する元ファイルの合計サイズの上限（モデル・モーションそれぞれ）
+//   - 上限を超えるファイルは、1件でも保持しない
+const parseCacheMaxBytes = 256 * 1024 * 1024
+
+// ParseCache PMX・VMDの解析結果をファイル内容のハッシュ毎に保持する
+//   - 同じ内容のファイルは一度だけ解析し、以降は複製を返す
+//   - 初回は解析結果をそのまま返し、保持用の複製を1つだけ作る
+//   - 保持している解析結果そのものは返さないため、呼び出し側で自由に加工してよい
+type ParseCache struct {
+	mutex       sync.Mutex
+	models      map[string]*pmx.PmxModel
+	motions     map[string]*vmd.VmdMotion
+	modelKeys   []parseCacheKey // 古い順
+	motionKeys  []parseCacheKey // 古い順
+	modelBytes  int64           // 保持しているモデルの元ファイルの合計サイズ
+	motionBytes int64           // 保持しているモーションの元ファイルの合計サイズ
+}
+
+// parseCacheKey 保持している解析結果のハッシュと元ファイルのサイズ
+type parseCacheKey struct {
+	hash string
+	size int64
+}
+
+// NewParseCache コンストラクタ
+func NewParseCache() *ParseCache {
+	return &ParseCache{
+		models:     make(map[string]*pmx.PmxModel),
+		motions:    make(map[string]*vmd.VmdMotion),
+		modelKeys:  make([]parseCacheKey, 0),
+		motionKeys: make([]parseCacheKey, 0),
+	}
+}
+
+// LoadModel PMXモデルを読み込み、解析結果の複製を返す
+func (c *ParseCache) LoadModel(path string) (*pmx.PmxModel, error) {
+	key, err := fileHash(path)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	cached, ok := c.models[key.hash]
+	c.mutex.Unlock()
+
+	var model *pmx.PmxModel
+	if ok {
+		if model, err = cached.Copy(); err != nil {
+			return nil, err
+		}
+	} else {
+		// 解析中はロックしない（別ファイルの読み込みを待たせないため）
+		rep := repository.NewPmxRepository(true)
+		data, err := rep.Load(path)
+		if err != nil {
+			return nil, err
+		}
+		model = data.(*pmx.PmxModel)
+
+		if key.size <= parseCacheMaxBytes {
+			if cached, err = model.Copy(); err != nil {
+				return nil, err
+			}
+
+			c.mutex.Lock()
+			if _, ok := c.models[key.hash]; !ok {
+				c.models[key.hash] = cached
+				c.modelKeys = c.appendKey(c.modelKeys, &c.modelBytes, key, func(hash string) { delete(c.models, hash) })
+			}
+			c.mutex.Unlock()
+		}
+	}
+	model.SetPath(path)
+
+	return model, nil
+}
+
+// LoadMotion VMDモーションを読み込み、解析結果の複製を返す
+func (c *ParseCache) LoadMotion(path string) (*vmd.VmdMotion, error) {
+	key, err := fileHash(path)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	cached, ok := c.motions[key.hash]
+	c.mutex.Unlock()
+
+	var motion *vmd.VmdMotion
+	if ok {
+		if motion, err = cached.Copy(); err != nil {
+			return nil, err
+		}
+	} else {
+		// 解析中はロックしない（別ファイルの読み込みを待たせないため）
+		rep := repository.NewVmdRepository(true)
+		data, err := rep.Load(path)
+		if err != nil {
+			return nil, err
+		}
+		motion = data.(*vmd.VmdMotion)
+
+		if key.size <= parseCacheMaxBytes {
+			if cached, err = motion.Copy(); err != nil {
+				return nil, err
+			}
+
+			c.mutex.Lock()
+			if _, ok := c.motions[key.hash]; !ok {
+				c.motions[key.hash] = cached
+				c.motionKeys = c.appendKey(c.motionKeys, &c.motionBytes, key, func(hash string) { delete(c.motions, hash) })
+			}
+			c.mutex.Unlock()
+		}
+	}
+	motion.SetPath(path)
+
+	return motion, nil
+}
+
+// appendKey ハッシュを追加し、最大件数・合計サイズを超えた古い解析結果を破棄する
+func (c *ParseCache) appendKey(
+	keys []parseCacheKey, totalBytes *int64, key parseCacheKey, evict func(hash string),
+) []parseCacheKey {
+	keys = append(keys, key)
+	*totalBytes += key.size
+	for len(keys) > parseCacheLimit || *totalBytes > parseCacheMaxBytes {
+		evict(keys[0].hash)
+		*totalBytes -= keys[0].size
+		keys = keys[1:]
+	}
+	return keys
+}
+
+// fileHash ファイル内容のSHA-256ハッシュとファイルサイズ
+func fileHash(path string) (parseCacheKey, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return parseCacheKey{}, err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return parseCacheKey{}, err
+	}
+
+	return parseCacheKey{hash: hex.EncodeToString(hash.Sum(nil)), size: size}, nil
+}
@@ -0,0 +1,96 @@
+package infrastructure
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestFileHash(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	original := writeFile("a.pmx", "model")
+	sameContent := writeFile("b.pmx", "model")
+	otherContent := writeFile("c.pmx", "other")
+
+	keyA, err := fileHash(original)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyB, _ := fileHash(sameContent)
+	keyC, _ := fileHash(otherContent)
+
+	if keyA.hash != keyB.hash {
+		t.Errorf("same content hash differs: %s != %s", keyA.hash, keyB.hash)
+	}
+	if keyA.hash == keyC.hash {
+		t.Errorf("different content hash equals: %s", keyA.hash)
+	}
+	if keyA.size != int64(len("model")) {
+		t.Errorf("size = %d, want %d", keyA.size, len("model"))
+	}
+
+	if _, err := fileHash(filepath.Join(dir, "missing.pmx")); err == nil {
+		t.Errorf("missing file returned no error")
+	}
+}
+
+func TestParseCache_appendKey(t *testing.T) {
+	tests := []struct {
+		name        string
+		sizes       []int64
+		wantKeys    []string
+		wantEvicted []string
+	}{
+		{
+			name:        "件数超過",
+			sizes:       []int64{1, 1, 1, 1, 1, 1},
+			wantKeys:    []string{"c", "d", "e", "f"},
+			wantEvicted: []string{"a", "b"},
+		},
+		{
+			name:        "合計サイズ超過",
+			sizes:       []int64{parseCacheMaxBytes / 2, parseCacheMaxBytes / 2, 1},
+			wantKeys:    []string{"b", "c"},
+			wantEvicted: []string{"a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewParseCache()
+			evicted := make([]string, 0)
+
+			keys := make([]parseCacheKey, 0)
+			totalBytes := int64(0)
+			for i, size := range tt.sizes {
+				key := parseCacheKey{hash: string(rune('a' + i)), size: size}
+				keys = c.appendKey(keys, &totalBytes, key, func(hash string) { evicted = append(evicted, hash) })
+			}
+
+			gotKeys := make([]string, 0, len(keys))
+			wantBytes := int64(0)
+			for _, key := range keys {
+				gotKeys = append(gotKeys, key.hash)
+				wantBytes += key.size
+			}
+			if !slices.Equal(gotKeys, tt.wantKeys) {
+				t.Errorf("keys = %v, want %v", gotKeys, tt.wantKeys)
+			}
+			if !slices.Equal(evicted, tt.wantEvicted) {
+				t.Errorf("evicted = %v, want %v", evicted, tt.wantEvicted)
+			}
+			if totalBytes != wantBytes {
+				t.Errorf("totalBytes = %d, want %d", totalBytes, wantBytes)
+			}
+		})
+	}
+}
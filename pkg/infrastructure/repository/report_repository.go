@@ -0,0 +1,118 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/miu200521358/bone_baker/pkg/domain/entity"
+	"github.com/miu200521358/mlib_go/pkg/config/mi18n"
+	"github.com/miu200521358/mlib_go/pkg/config/mlog"
+	"github.com/miu200521358/mlib_go/pkg/infrastructure/mfile"
+)
+
+type ReportRepository struct{}
+
+// NewReportRepository コンストラクタ
+func NewReportRepository() *ReportRepository {
+	return &ReportRepository{}
+}
+
+// Save 焼き込みレポートを出力モーションと同じ場所にJSONとMarkdownで保存
+func (r *ReportRepository) Save(report *entity.BakeReport, outputMotionPath string) error {
+	dirPath, fileName, _ := mfile.SplitPath(outputMotionPath)
+	basePath := filepath.Join(dirPath, fmt.Sprintf("%s_report", fileName))
+
+	output, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		mlog.E(mi18n.T("焼き込みレポート保存失敗エラー"), err, "")
+		return err
+	}
+
+	if err := os.WriteFile(basePath+".json", output, 0644); err != nil {
+		mlog.E(mi18n.T("焼き込みレポート保存失敗エラー"), err, "")
+		return err
+	}
+
+	if err := os.WriteFile(basePath+".md", []byte(r.markdown(report)), 0644); err != nil {
+		mlog.E(mi18n.T("焼き込みレポート保存失敗エラー"), err, "")
+		return err
+	}
+
+	mlog.I(mi18n.T("焼き込みレポート保存成功", map[string]any{"Path": basePath}))
+	return nil
+}
+
+// markdown レビュー用にMarkdown形式のレポートを生成
+func (r *ReportRepository) markdown(report *entity.BakeReport) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "# %s\n\n", mi18n.T("焼き込みレポート"))
+	fmt.Fprintf(&sb, "- %s: `%s`\n", mi18n.T("元モデル"), report.ModelPath)
+	fmt.Fprintf(&sb, "- %s: `%s`\n", mi18n.T("元モーション"), report.OriginalMotionPath)
+	fmt.Fprintf(&sb, "- %s: `%s`\n\n", mi18n.T("出力モーション"), report.OutputMotionPath)
+
+	fmt.Fprintf(&sb, "## %s\n\n", mi18n.T("出力ファイル"))
+	fmt.Fprintf(&sb, "| # | %s | %s | %s |\n", mi18n.T("ファイル"), mi18n.T("フレーム範囲"), mi18n.T("キーフレーム数"))
+	sb.WriteString("|---:|---|---|---:|\n")
+	for i, file := range report.Files {
+		fmt.Fprintf(&sb, "| %d | `%s` | %.0f - %.0f | %d |\n",
+			i+1, filepath.Base(file.Path), file.StartFrame, file.EndFrame, file.KeyCount)
+	}
+	sb.WriteString("\n")
+
+	fmt.Fprintf(&sb, "## %s\n\n", mi18n.T("ボーン別出力"))
+	fmt.Fprintf(&sb, "| %s | %s | %s | %s | %s | %s |\n",
+		mi18n.T("ボーン"), mi18n.T("元ボーン名"), mi18n.T("元モーション"), mi18n.T("焼き込み"), mi18n.T("間引き"), mi18n.T("キーフレーム数"))
+	sb.WriteString("|---|---|---:|---:|---:|---:|\n")
+	for _, bone := range report.Bones {
+		fmt.Fprintf(&sb, "| %s | %s | %d | %d | %d | %d |\n",
+			bone.BoneName, bone.OriginalBoneName, bone.OriginalFrameCount, bone.BakeFrameCount, bone.ReduceFrameCount, bone.KeyCount)
+	}
+
+	if len(report.BoneNameMap) > 0 {
+		bakeBoneNames := make([]string, 0, len(report.BoneNameMap))
+		for bakeBoneName := range report.BoneNameMap {
+			bakeBoneNames = append(bakeBoneNames, bakeBoneName)
+		}
+		sort.Strings(bakeBoneNames)
+
+		fmt.Fprintf(&sb, "\n## %s\n\n", mi18n.T("ボーン名対応表"))
+		fmt.Fprintf(&sb, "| %s | %s |\n", mi18n.T("焼き込みボーン名"), mi18n.T("元ボーン名"))
+		sb.WriteString("|---|---|\n")
+		for _, bakeBoneName := range bakeBoneNames {
+			fmt.Fprintf(&sb, "| %s | %s |\n", bakeBoneName, report.BoneNameMap[bakeBoneName])
+		}
+	}
+
+	if len(report.BoneAliasMap) > 0 {
+		motionBoneNames := make([]string, 0, len(report.BoneAliasMap))
+		for motionBoneName := range report.BoneAliasMap {
+			motionBoneNames = append(motionBoneNames, motionBoneName)
+		}
+		sort.Strings(motionBoneNames)
+
+		fmt.Fprintf(&sb, "\n## %s\n\n", mi18n.T("ボーン別名対応表"))
+		fmt.Fprintf(&sb, "| %s | %s |\n", mi18n.T("モーションのボーン名"), mi18n.T("モデルのボーン名"))
+		sb.WriteString("|---|---|\n")
+		for _, motionBoneName := range motionBoneNames {
+			fmt.Fprintf(&sb, "| %s | %s |\n", motionBoneName, report.BoneAliasMap[motionBoneName])
+		}
+	}
+
+	if len(report.Reduces) > 0 {
+		fmt.Fprintf(&sb, "\n## %s\n\n", mi18n.T("間引き結果"))
+		fmt.Fprintf(&sb, "| %s | %s | %s | %s |\n",
+			mi18n.T("ボーン"), mi18n.T("キーフレーム数"), mi18n.T("最大位置誤差"), mi18n.T("最大回転誤差"))
+		sb.WriteString("|---|---:|---:|---:|\n")
+		for _, reduce := range report.Reduces {
+			fmt.Fprintf(&sb, "| %s | %d / %d | %.4f | %.4f |\n",
+				reduce.BoneName, reduce.KeyCount, reduce.FrameCount, reduce.MaxPositionError, reduce.MaxRotationError)
+		}
+	}
+
+	return sb.String()
+}
@@ -78,6 +78,14 @@ func NewBakePage(mWidgets *controller.MWidgets) declarative.TabPage {
 				Children: []declarative.Widget{
 					store.OriginalModelPicker.Widgets(),
 					store.OriginalMotionPicker.Widgets(),
+					declarative.Composite{
+						Layout:   declarative.HBox{},
+						Children: store.createMergeMotionWidgets(),
+					},
+					declarative.Composite{
+						Layout:   declarative.HBox{},
+						Children: store.createBoneAliasWidgets(),
+					},
 					declarative.VSeparator{},
 					declarative.Composite{
 						Layout:  declarative.HBox{},
@@ -126,6 +134,18 @@ func NewBakePage(mWidgets *controller.MWidgets) declarative.TabPage {
 								},
 							},
 							declarative.HSpacer{},
+							declarative.CheckBox{
+								AssignTo:    &store.WindScopeCheckBox,
+								Text:        mi18n.T("セット個別の風"),
+								ToolTipText: mi18n.T("セット個別の風説明"),
+								OnClicked: func() {
+									if store.WindScopeCheckBox.Checked() {
+										store.changeWindScope(entity.WindScopeSet)
+									} else {
+										store.changeWindScope(entity.WindScopeGlobal)
+									}
+								},
+							},
 							store.AddWindButton.Widgets(),
 						},
 					},
@@ -154,9 +174,30 @@ func NewBakePage(mWidgets *controller.MWidgets) declarative.TabPage {
 					createOutputTableView(store),
 					declarative.VSeparator{},
 					store.OutputModelPicker.Widgets(),
-					store.SaveModelButton.Widgets(),
+					declarative.Composite{
+						Layout: declarative.HBox{},
+						Children: []declarative.Widget{
+							declarative.CheckBox{
+								AssignTo:    &store.CleanModelCheckBox,
+								Text:        mi18n.T("未加工モデルで保存"),
+								ToolTipText: mi18n.T("未加工モデルで保存説明"),
+								OnClicked: func() {
+									store.currentSet().OutputCleanModel = store.CleanModelCheckBox.Checked()
+								},
+							},
+							store.SaveModelButton.Widgets(),
+						},
+					},
+					declarative.Composite{
+						Layout:   declarative.Grid{Columns: 4},
+						Children: store.createOutputPhysicsWidgets(),
+					},
 					declarative.VSeparator{},
 					store.OutputMotionPicker.Widgets(),
+					declarative.Composite{
+						Layout:   declarative.Grid{Columns: 4},
+						Children: store.createOutputSplitWidgets(),
+					},
 					store.SaveMotionButton.Widgets(),
 					store.TerminateMotionButton.Widgets(),
 				},
@@ -49,8 +49,8 @@ func (p *OutputTableViewDialog) show(record *entity.OutputRecord, recordIndex in
 		DefaultButton: &okBtn,
 		Title:         mi18n.T("出力設定"),
 		Layout:        declarative.VBox{},
-		MinSize:       declarative.Size{Width: 500, Height: 400},
-		MaxSize:       declarative.Size{Width: 500, Height: 400},
+		MinSize:       declarative.Size{Width: 500, Height: 510},
+		MaxSize:       declarative.Size{Width: 500, Height: 510},
 		DataBinder: declarative.DataBinder{
 			AssignTo:   &db,
 			DataSource: record,
@@ -139,6 +139,155 @@ func (p *OutputTableViewDialog) createFormWidgets(startFrameEdit, endFrameEdit *
 			Text:        mi18n.T("間引き"),
 			ToolTipText: mi18n.T("間引き説明"),
 		},
+		declarative.Label{
+			Text:        mi18n.T("間引き位置誤差"),
+			ToolTipText: mi18n.T("間引き位置誤差説明"),
+			OnMouseDown: func(x, y int, button walk.MouseButton) {
+				mlog.IL("%s", mi18n.T("間引き位置誤差説明"))
+			},
+			MinSize: declarative.Size{Width: 100, Height: 20},
+			MaxSize: declarative.Size{Width: 100, Height: 20},
+		},
+		declarative.NumberEdit{
+			Value:              declarative.Bind("ReducePositionTolerance"),
+			ToolTipText:        mi18n.T("間引き位置誤差説明"),
+			SpinButtonsVisible: true,
+			Decimals:           3,
+			Increment:          0.01,
+			MinValue:           0,
+			MaxValue:           10,
+			MinSize:            declarative.Size{Width: 100, Height: 20},
+			MaxSize:            declarative.Size{Width: 100, Height: 20},
+		},
+		declarative.Label{
+			Text:        mi18n.T("間引き回転誤差"),
+			ToolTipText: mi18n.T("間引き回転誤差説明"),
+			OnMouseDown: func(x, y int, button walk.MouseButton) {
+				mlog.IL("%s", mi18n.T("間引き回転誤差説明"))
+			},
+			MinSize: declarative.Size{Width: 100, Height: 20},
+			MaxSize: declarative.Size{Width: 100, Height: 20},
+		},
+		declarative.NumberEdit{
+			Value:              declarative.Bind("ReduceRotationTolerance"),
+			ToolTipText:        mi18n.T("間引き回転誤差説明"),
+			SpinButtonsVisible: true,
+			Decimals:           2,
+			Increment:          0.1,
+			MinValue:           0,
+			MaxValue:           180,
+			MinSize:            declarative.Size{Width: 100, Height: 20},
+			MaxSize:            declarative.Size{Width: 100, Height: 20},
+		},
+		declarative.HSpacer{},
+		declarative.Label{
+			Text:        mi18n.T("間引き最大間隔"),
+			ToolTipText: mi18n.T("間引き最大間隔説明"),
+			OnMouseDown: func(x, y int, button walk.MouseButton) {
+				mlog.IL("%s", mi18n.T("間引き最大間隔説明"))
+			},
+			MinSize: declarative.Size{Width: 100, Height: 20},
+			MaxSize: declarative.Size{Width: 100, Height: 20},
+		},
+		declarative.NumberEdit{
+			Value:              declarative.Bind("ReduceMaxInterval"),
+			ToolTipText:        mi18n.T("間引き最大間隔説明"),
+			SpinButtonsVisible: true,
+			Decimals:           0,
+			Increment:          1,
+			MinValue:           0,
+			MaxValue:           10000,
+			MinSize:            declarative.Size{Width: 100, Height: 20},
+			MaxSize:            declarative.Size{Width: 100, Height: 20},
+		},
+		declarative.Label{
+			Text:        mi18n.T("間引き方法"),
+			ToolTipText: mi18n.T("間引き方法説明"),
+			OnMouseDown: func(x, y int, button walk.MouseButton) {
+				mlog.IL("%s", mi18n.T("間引き方法説明"))
+			},
+			MinSize: declarative.Size{Width: 100, Height: 20},
+			MaxSize: declarative.Size{Width: 100, Height: 20},
+		},
+		declarative.ComboBox{
+			CurrentIndex: declarative.Bind("ReduceType"),
+			ToolTipText:  mi18n.T("間引き方法説明"),
+			Model: []string{
+				mi18n.T("線形補間"),
+				mi18n.T("補間曲線"),
+			},
+			MinSize: declarative.Size{Width: 100, Height: 20},
+			MaxSize: declarative.Size{Width: 100, Height: 20},
+		},
+		declarative.CheckBox{
+			Checked:     declarative.Bind("Additive"),
+			Text:        mi18n.T("加算焼き込み"),
+			ToolTipText: mi18n.T("加算焼き込み説明"),
+		},
+		declarative.Label{
+			Text:        mi18n.T("加算割合"),
+			ToolTipText: mi18n.T("加算割合説明"),
+			OnMouseDown: func(x, y int, button walk.MouseButton) {
+				mlog.IL("%s", mi18n.T("加算割合説明"))
+			},
+			MinSize: declarative.Size{Width: 100, Height: 20},
+			MaxSize: declarative.Size{Width: 100, Height: 20},
+		},
+		declarative.NumberEdit{
+			Value:              declarative.Bind("AdditiveWeight"),
+			ToolTipText:        mi18n.T("加算割合説明"),
+			SpinButtonsVisible: true,
+			Decimals:           2,
+			Increment:          0.05,
+			MinValue:           0,
+			MaxValue:           1,
+			MinSize:            declarative.Size{Width: 100, Height: 20},
+			MaxSize:            declarative.Size{Width: 100, Height: 20},
+		},
+		declarative.HSpacer{
+			ColumnSpan: 2,
+		},
+		declarative.HSpacer{},
+		declarative.Label{
+			Text:        mi18n.T("ブレンドイン"),
+			ToolTipText: mi18n.T("ブレンドイン説明"),
+			OnMouseDown: func(x, y int, button walk.MouseButton) {
+				mlog.IL("%s", mi18n.T("ブレンドイン説明"))
+			},
+			MinSize: declarative.Size{Width: 100, Height: 20},
+			MaxSize: declarative.Size{Width: 100, Height: 20},
+		},
+		declarative.NumberEdit{
+			Value:              declarative.Bind("BlendInFrames"),
+			ToolTipText:        mi18n.T("ブレンドイン説明"),
+			SpinButtonsVisible: true,
+			Decimals:           0,
+			Increment:          1,
+			MinValue:           0,
+			MaxValue:           10000,
+			MinSize:            declarative.Size{Width: 100, Height: 20},
+			MaxSize:            declarative.Size{Width: 100, Height: 20},
+		},
+		declarative.Label{
+			Text:        mi18n.T("ブレンドアウト"),
+			ToolTipText: mi18n.T("ブレンドアウト説明"),
+			OnMouseDown: func(x, y int, button walk.MouseButton) {
+				mlog.IL("%s", mi18n.T("ブレンドアウト説明"))
+			},
+			MinSize: declarative.Size{Width: 100, Height: 20},
+			MaxSize: declarative.Size{Width: 100, Height: 20},
+		},
+		declarative.NumberEdit{
+			Value:              declarative.Bind("BlendOutFrames"),
+			ToolTipText:        mi18n.T("ブレンドアウト説明"),
+			SpinButtonsVisible: true,
+			Decimals:           0,
+			Increment:          1,
+			MinValue:           0,
+			MaxValue:           10000,
+			MinSize:            declarative.Size{Width: 100, Height: 20},
+			MaxSize:            declarative.Size{Width: 100, Height: 20},
+		},
 		declarative.Label{
 			Text: mi18n.T("出力対象ボーン"),
 		},
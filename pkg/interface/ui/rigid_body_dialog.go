@@ -30,6 +30,11 @@ type RigidBodyTableViewDialog struct {
 	massEdit          *walk.NumberEdit // 質量入力
 	stiffnessEdit     *walk.NumberEdit // 硬さ入力
 	tensionEdit       *walk.NumberEdit // 張り入力
+	easingComboBox    *walk.ComboBox   // 補間方法選択
+	easingX1Edit      *walk.NumberEdit // 補間制御点1X入力
+	easingY1Edit      *walk.NumberEdit // 補間制御点1Y入力
+	easingX2Edit      *walk.NumberEdit // 補間制御点2X入力
+	easingY2Edit      *walk.NumberEdit // 補間制御点2Y入力
 	treeView          *walk.TreeView   // 剛体ツリービュー
 }
 
@@ -52,14 +57,18 @@ func (p *RigidBodyTableViewDialog) Show(record *entity.RigidBodyRecord, recordIn
 	builder := declarative.NewBuilder(p.store.Window())
 	treeModel := newRigidBodyTreeModel(record)
 
+	if record.Easing == nil {
+		record.Easing = entity.NewEasing(entity.EasingTypeLinear)
+	}
+
 	dialog := &declarative.Dialog{
 		AssignTo:      &dlg,
 		CancelButton:  &cancelBtn,
 		DefaultButton: &okBtn,
 		Title:         mi18n.T("モデル物理設定"),
 		Layout:        declarative.VBox{},
-		MinSize:       declarative.Size{Width: 500, Height: 400},
-		MaxSize:       declarative.Size{Width: 500, Height: 400},
+		MinSize:       declarative.Size{Width: 500, Height: 430},
+		MaxSize:       declarative.Size{Width: 500, Height: 430},
 		DataBinder: declarative.DataBinder{
 			AssignTo:   &db,
 			DataSource: record,
@@ -67,7 +76,7 @@ func (p *RigidBodyTableViewDialog) Show(record *entity.RigidBodyRecord, recordIn
 		Children: []declarative.Widget{
 			declarative.Composite{
 				Layout:   declarative.Grid{Columns: 6},
-				Children: p.createFormWidgets(&p.treeView, treeModel),
+				Children: p.createFormWidgets(&p.treeView, treeModel, record.Easing),
 			},
 			declarative.Composite{
 				Layout: declarative.HBox{
@@ -85,7 +94,9 @@ func (p *RigidBodyTableViewDialog) Show(record *entity.RigidBodyRecord, recordIn
 	}
 }
 
-func (p *RigidBodyTableViewDialog) createFormWidgets(treeView **walk.TreeView, treeModel *RigidBodyTreeModel) []declarative.Widget {
+func (p *RigidBodyTableViewDialog) createFormWidgets(
+	treeView **walk.TreeView, treeModel *RigidBodyTreeModel, easing *entity.Easing,
+) []declarative.Widget {
 
 	return []declarative.Widget{
 		declarative.Label{
@@ -194,6 +205,36 @@ func (p *RigidBodyTableViewDialog) createFormWidgets(treeView **walk.TreeView, t
 		declarative.HSpacer{
 			ColumnSpan: 2,
 		},
+		declarative.Label{
+			Text:        mi18n.T("補間方法"),
+			ToolTipText: mi18n.T("補間方法説明"),
+			OnMouseDown: func(x, y int, button walk.MouseButton) {
+				mlog.IL("%s", mi18n.T("補間方法説明"))
+			},
+			MinSize: declarative.Size{Width: 150, Height: 20},
+			MaxSize: declarative.Size{Width: 150, Height: 20},
+		},
+		declarative.ComboBox{
+			AssignTo: &p.easingComboBox,
+			Model: []string{
+				mi18n.T("線形"),
+				mi18n.T("イーズイン"),
+				mi18n.T("イーズアウト"),
+				mi18n.T("イーズインアウト"),
+				mi18n.T("ステップ"),
+				mi18n.T("ベジェ曲線"),
+			},
+			CurrentIndex: easing.Type,
+			MinSize:      declarative.Size{Width: 80, Height: 20},
+			MaxSize:      declarative.Size{Width: 80, Height: 20},
+			OnCurrentIndexChanged: func() {
+				p.onChangeEasingType()
+			},
+		},
+		p.createEasingPointEdit(&p.easingX1Edit, easing.X1, easing.Type == entity.EasingTypeBezier),
+		p.createEasingPointEdit(&p.easingY1Edit, easing.Y1, easing.Type == entity.EasingTypeBezier),
+		p.createEasingPointEdit(&p.easingX2Edit, easing.X2, easing.Type == entity.EasingTypeBezier),
+		p.createEasingPointEdit(&p.easingY2Edit, easing.Y2, easing.Type == entity.EasingTypeBezier),
 		declarative.TextLabel{
 			Text:        mi18n.T("位置X"),
 			ToolTipText: mi18n.T("位置X説明"),
@@ -419,6 +460,71 @@ func (p *RigidBodyTableViewDialog) createFormWidgets(treeView **walk.TreeView, t
 	}
 }
 
+// createEasingPointEdit ベジェ曲線の制御点入力（VMDの補間曲線と同じ 0～127）
+func (p *RigidBodyTableViewDialog) createEasingPointEdit(
+	edit **walk.NumberEdit, value int, enabled bool,
+) declarative.Widget {
+	return declarative.NumberEdit{
+		AssignTo:           edit,
+		Enabled:            enabled,
+		ToolTipText:        mi18n.T("補間制御点説明"),
+		Value:              float64(value),
+		MinValue:           0,
+		MaxValue:           127,
+		Decimals:           0,
+		Increment:          1,
+		SpinButtonsVisible: true,
+		MinSize:            declarative.Size{Width: 50, Height: 20},
+		MaxSize:            declarative.Size{Width: 50, Height: 20},
+		OnValueChanged: func() {
+			p.onChangeValue()
+		},
+	}
+}
+
+// onChangeEasingType 補間方法の変更に合わせて制御点を更新
+func (p *RigidBodyTableViewDialog) onChangeEasingType() {
+	easingType := p.easingComboBox.CurrentIndex()
+	isBezier := easingType == entity.EasingTypeBezier
+
+	for _, edit := range []*walk.NumberEdit{p.easingX1Edit, p.easingY1Edit, p.easingX2Edit, p.easingY2Edit} {
+		if edit != nil {
+			edit.SetEnabled(isBezier)
+		}
+	}
+
+	if !isBezier && p.easingX1Edit != nil {
+		// プリセットの制御点を表示
+		preset := entity.NewEasing(easingType)
+		p.easingX1Edit.ChangeValue(float64(preset.X1))
+		p.easingY1Edit.ChangeValue(float64(preset.Y1))
+		p.easingX2Edit.ChangeValue(float64(preset.X2))
+		p.easingY2Edit.ChangeValue(float64(preset.Y2))
+	}
+
+	p.onChangeValue()
+}
+
+// currentEasing 入力中の補間方法を取得
+func (p *RigidBodyTableViewDialog) currentEasing() *entity.Easing {
+	if p.easingComboBox == nil {
+		return entity.NewEasing(entity.EasingTypeLinear)
+	}
+
+	easingType := p.easingComboBox.CurrentIndex()
+	if easingType != entity.EasingTypeBezier {
+		return entity.NewEasing(easingType)
+	}
+
+	return &entity.Easing{
+		Type: easingType,
+		X1:   int(p.easingX1Edit.Value()),
+		Y1:   int(p.easingY1Edit.Value()),
+		X2:   int(p.easingX2Edit.Value()),
+		Y2:   int(p.easingY2Edit.Value()),
+	}
+}
+
 // updateEditValues 編集値を更新
 func (p *RigidBodyTableViewDialog) updateEditValues(treeView *walk.TreeView) {
 	if treeView.CurrentItem() == nil {
@@ -468,6 +574,7 @@ func (p *RigidBodyTableViewDialog) createButtonWidgets(
 					mlog.E(mi18n.T("焼き込み設定変更エラー"), err, "")
 					return
 				}
+				(*db).DataSource().(*entity.RigidBodyRecord).Easing = p.currentEasing()
 				(*dlg).Accept()
 			},
 		},
@@ -514,18 +621,7 @@ func (p *RigidBodyTableViewDialog) handleDialogOK(record *entity.RigidBodyRecord
 		}
 	}
 
-	physicsWorldMotion := p.store.mWidgets.Window().LoadPhysicsWorldMotion(0)
-	physicsModelMotion := vmd.NewVmdMotion("")
-
-	p.store.physicsUsecase.ApplyPhysicsModelMotion(
-		physicsWorldMotion,
-		physicsModelMotion,
-		p.store.currentSet().RigidBodyRecords,
-		p.store.currentSet().OriginalModel,
-	)
-
-	p.store.mWidgets.Window().StorePhysicsWorldMotion(0, physicsWorldMotion)
-	p.store.mWidgets.Window().StorePhysicsModelMotion(0, p.store.CurrentIndex, physicsModelMotion)
+	p.store.applyPhysicsModelMotion(p.store.CurrentIndex)
 	p.store.mWidgets.Window().TriggerPhysicsReset()
 
 	// 台形テーブルの再描画を強制
@@ -552,6 +648,7 @@ func (p *RigidBodyTableViewDialog) onChangeValue() {
 	record := entity.NewRigidBodyRecord(float32(p.startFrameEdit.Value()), float32(p.endFrameEdit.Value()), p.store.currentSet().OriginalModel)
 	record.MaxStartFrame = float32(p.maxStartFrameEdit.Value())
 	record.MaxEndFrame = float32(p.maxEndFrameEdit.Value())
+	record.Easing = p.currentEasing()
 
 	p.updateItemProperty(func(item *RigidBodyTreeItem) {
 		item.CalcPositionX((p.positionXEdit).Value())
@@ -571,6 +668,7 @@ func (p *RigidBodyTableViewDialog) onChangeValue() {
 		[]*entity.RigidBodyRecord{record},
 		p.store.currentSet().OriginalModel,
 	)
+	p.store.physicsUsecase.ApplyBakeSetWindMotion(physicsModelMotion, p.store.currentSet(), p.store.WindRecords)
 
 	p.store.mWidgets.Window().StorePhysicsWorldMotion(0, physicsWorldMotion)
 	p.store.mWidgets.Window().StorePhysicsModelMotion(0, p.store.CurrentIndex, physicsModelMotion)
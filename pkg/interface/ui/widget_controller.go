@@ -1,6 +1,8 @@
 package ui
 
 import (
+	"github.com/miu200521358/bone_baker/pkg/domain/entity"
+	"github.com/miu200521358/mlib_go/pkg/domain/vmd"
 	"github.com/miu200521358/mlib_go/pkg/interface/controller"
 )
 
@@ -14,6 +16,10 @@ func (s *WidgetStore) loadMotion(cw *controller.ControlWindow, path string) erro
 
 	// UI反映処理
 	currentSet := s.currentSet()
+
+	// モデルとの対応状況をログに出力
+	s.logCompatibility(currentSet)
+
 	cw.StoreMotion(0, s.CurrentIndex, currentSet.OriginalMotion)
 	cw.StoreMotion(1, s.CurrentIndex, currentSet.OutputMotion)
 
@@ -40,6 +46,16 @@ func (s *WidgetStore) loadMotion(cw *controller.ControlWindow, path string) erro
 	return nil
 }
 
+// logCompatibility モデルとモーションが揃っている場合、名前の対応状況をログに出力する
+func (s *WidgetStore) logCompatibility(bakeSet *entity.BakeSet) {
+	if bakeSet.OriginalModel == nil || bakeSet.OriginalMotion == nil {
+		return
+	}
+
+	s.validationUsecase.LogCompatibility(
+		s.validationUsecase.CheckCompatibility(bakeSet.OriginalModel, bakeSet.OriginalMotion, bakeSet.BoneNameMap))
+}
+
 func (s *WidgetStore) loadModel(cw *controller.ControlWindow, path string) error {
 	s.setWidgetEnabled(false)
 
@@ -49,6 +65,11 @@ func (s *WidgetStore) loadModel(cw *controller.ControlWindow, path string) error
 
 	// UI反映処理
 	currentSet := s.currentSet()
+
+	// 焼き込み前にモデルの問題を検査してログに出力
+	s.validationUsecase.LogIssues(s.validationUsecase.Validate(currentSet.OriginalModel, currentSet.BoneNameMap))
+	s.logCompatibility(currentSet)
+
 	cw.StoreModel(0, s.CurrentIndex, currentSet.OriginalModel)
 	cw.StoreModel(1, s.CurrentIndex, currentSet.BakedModel)
 
@@ -75,7 +96,7 @@ func (s *WidgetStore) loadModel(cw *controller.ControlWindow, path string) error
 }
 
 func (s *WidgetStore) saveBakeSets(filePath string) error {
-	return s.saveUsecase.SaveFile(s.BakeSets, s.PhysicsRecords, filePath)
+	return s.saveUsecase.SaveFile(s.BakeSets, s.PhysicsRecords, s.WindRecords, filePath)
 }
 
 func (s *WidgetStore) loadBakeSets(filePath string) {
@@ -89,11 +110,12 @@ func (s *WidgetStore) loadBakeSets(filePath string) {
 	}
 
 	s.resetStore()
-	var err error
-	s.BakeSets, s.PhysicsRecords, err = s.loadUsecase.LoadFile(filePath)
+	bakeSets, physicsRecords, windRecords, err := s.loadUsecase.LoadFile(filePath)
 	if err != nil {
+		s.setWidgetEnabled(true)
 		return
 	}
+	s.BakeSets, s.PhysicsRecords, s.WindRecords = bakeSets, physicsRecords, windRecords
 
 	for range len(s.BakeSets) - 1 {
 		s.AddAction()
@@ -116,8 +138,87 @@ func (s *WidgetStore) loadBakeSets(filePath string) {
 	)
 
 	s.mWidgets.Window().StorePhysicsWorldMotion(0, physicsWorldMotion)
+
+	s.changeCurrentAction(0)
+	s.applyWindMotions()
 	s.mWidgets.Window().TriggerPhysicsReset()
 
-	s.CurrentIndex = 0
 	s.setWidgetEnabled(true)
 }
+
+// currentWindRecords 現在のセットで編集対象となる風設定レコード
+func (s *WidgetStore) currentWindRecords() []*entity.WindRecord {
+	if s.currentSet() != nil && s.currentSet().WindScope == entity.WindScopeSet {
+		return s.currentSet().WindRecords
+	}
+	return s.WindRecords
+}
+
+// setCurrentWindRecords 現在のセットで編集対象となる風設定レコードを更新
+func (s *WidgetStore) setCurrentWindRecords(records []*entity.WindRecord) {
+	if s.currentSet() != nil && s.currentSet().WindScope == entity.WindScopeSet {
+		s.currentSet().WindRecords = records
+		return
+	}
+	s.WindRecords = records
+}
+
+// changeWindScope 現在のセットの風設定の適用範囲を変更
+func (s *WidgetStore) changeWindScope(scope entity.WindScope) {
+	if s.currentSet() == nil {
+		return
+	}
+
+	s.currentSet().WindScope = scope
+	s.WindTableView.SetModel(newWindTableModelWithRecords(s.currentWindRecords()))
+
+	s.applyWindMotions()
+	s.mWidgets.Window().TriggerPhysicsReset()
+}
+
+// applyWindMotions 全体共通の風とセット個別の風をビューワーに反映
+//   - 全体共通の風はワールドに、セット個別の風は各セットのモデル物理に反映する
+//   - セット個別の風を使うセットには、全体共通の風を掛けない
+func (s *WidgetStore) applyWindMotions() {
+	windMotion := vmd.NewVmdMotion("")
+
+	s.physicsUsecase.ApplyWindMotion(
+		windMotion,
+		s.WindRecords,
+	)
+
+	s.mWidgets.Window().StoreWindMotion(0, windMotion)
+
+	for index := range s.BakeSets {
+		s.applyPhysicsModelMotion(index)
+	}
+}
+
+// applyPhysicsModelMotion 指定セットのモデル物理（セット個別の風を含む）をビューワーに反映
+func (s *WidgetStore) applyPhysicsModelMotion(index int) {
+	s.applyPhysicsModelMotionWithWind(index, s.BakeSets[index].WindRecords)
+}
+
+// applyPhysicsModelMotionWithWind 指定セットのモデル物理を、指定したセット個別の風設定でビューワーに反映
+func (s *WidgetStore) applyPhysicsModelMotionWithWind(index int, windRecords []*entity.WindRecord) {
+	bakeSet := s.BakeSets[index]
+	if bakeSet.OriginalModel == nil {
+		return
+	}
+
+	physicsWorldMotion := s.mWidgets.Window().LoadPhysicsWorldMotion(0)
+	physicsModelMotion := vmd.NewVmdMotion("")
+
+	s.physicsUsecase.ApplyPhysicsModelMotion(
+		physicsWorldMotion,
+		physicsModelMotion,
+		bakeSet.RigidBodyRecords,
+		bakeSet.OriginalModel,
+	)
+	if bakeSet.WindScope == entity.WindScopeSet {
+		s.physicsUsecase.ApplyModelWindMotion(physicsModelMotion, windRecords, s.WindRecords)
+	}
+
+	s.mWidgets.Window().StorePhysicsWorldMotion(0, physicsWorldMotion)
+	s.mWidgets.Window().StorePhysicsModelMotion(0, index, physicsModelMotion)
+}
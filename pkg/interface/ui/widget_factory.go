@@ -3,6 +3,10 @@ package ui
 import (
 	"fmt"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 	"sync/atomic"
 	"time"
 
@@ -53,6 +57,11 @@ func (s *WidgetStore) setWidgetEnabled(enabled bool) {
 
 	s.OriginalMotionPicker.SetEnabled(enabled)
 	s.OriginalModelPicker.SetEnabled(enabled)
+	s.MergeMotionComboBox.SetEnabled(enabled)
+	s.MergeMotionButton.SetEnabled(enabled)
+	s.MergeMotionClearButton.SetEnabled(enabled)
+	s.BoneAliasButton.SetEnabled(enabled)
+	s.BoneAliasClearButton.SetEnabled(enabled)
 	s.OutputMotionPicker.SetEnabled(enabled)
 	s.OutputModelPicker.SetEnabled(enabled)
 
@@ -125,6 +134,10 @@ func (s *WidgetStore) createButtonWidgets() {
 	s.ResetSetButton = s.createResetSetButton()
 	s.LoadSetButton = s.createLoadSetButton()
 	s.SaveSetButton = s.createSaveSetButton()
+	s.MergeMotionButton = s.createMergeMotionButton()
+	s.MergeMotionClearButton = s.createMergeMotionClearButton()
+	s.BoneAliasButton = s.createBoneAliasButton()
+	s.BoneAliasClearButton = s.createBoneAliasClearButton()
 	s.SaveModelButton = s.createSaveModelButton()
 	s.SaveMotionButton = s.createSaveMotionButton()
 	s.TerminateMotionButton = s.createTerminateMotionButton()
@@ -150,12 +163,11 @@ func (s *WidgetStore) createOutputModelFilePicker() *widget.FilePicker {
 		mi18n.T("変更後モデル説明"),
 		func(cw *controller.ControlWindow, rep repository.IRepository, path string) {
 			// 実際に保存するのは、物理有効な元モデル
-			model := s.currentSet().OriginalModel
-			if model == nil {
+			if s.currentSet().OriginalModel == nil {
 				return
 			}
 
-			if err := rep.Save(path, model, false); err != nil {
+			if err := s.saveUsecase.SaveOutputModel(s.currentSet(), path); err != nil {
 				mlog.ET(mi18n.T("保存失敗"), err, "")
 				if ok := merr.ShowErrorDialog(cw.AppConfig(), err); ok {
 					s.setWidgetEnabled(true)
@@ -180,6 +192,193 @@ func (s *WidgetStore) createOriginalMotionFilePicker() *widget.FilePicker {
 	)
 }
 
+// createMergeMotionWidgets 元モーションに重ねるモーションの設定ウィジェット群
+func (s *WidgetStore) createMergeMotionWidgets() []declarative.Widget {
+	return []declarative.Widget{
+		declarative.TextLabel{
+			Text:        mi18n.T("重ねるモーション"),
+			ToolTipText: mi18n.T("重ねるモーション説明"),
+			OnMouseDown: func(x, y int, button walk.MouseButton) {
+				mlog.ILT(mi18n.T("重ねるモーション"), mi18n.T("重ねるモーション説明"))
+			},
+		},
+		declarative.TextLabel{
+			AssignTo: &s.MergeMotionLabel,
+			Text:     mi18n.T("重ねるモーション件数", map[string]any{"Count": 0}),
+		},
+		declarative.HSpacer{},
+		declarative.ComboBox{
+			AssignTo: &s.MergeMotionComboBox,
+			Model: []string{
+				mi18n.T("後のモーションで置き換え"),
+				mi18n.T("全モーションのキーフレームを合わせる"),
+			},
+			CurrentIndex: entity.MotionMergeTypeOverride,
+			OnCurrentIndexChanged: func() {
+				if s.currentSet() == nil || s.MergeMotionComboBox == nil ||
+					s.currentSet().MotionMergeType == s.MergeMotionComboBox.CurrentIndex() {
+					return
+				}
+				s.currentSet().MotionMergeType = s.MergeMotionComboBox.CurrentIndex()
+				s.reloadMergeMotions(s.Window())
+			},
+		},
+		s.MergeMotionButton.Widgets(),
+		s.MergeMotionClearButton.Widgets(),
+	}
+}
+
+func (s *WidgetStore) createMergeMotionButton() *widget.MPushButton {
+	btn := widget.NewMPushButton()
+	btn.SetLabel(mi18n.T("重ねるモーション選択"))
+	btn.SetTooltip(mi18n.T("重ねるモーション選択説明"))
+	btn.SetMaxSize(declarative.Size{Width: 100, Height: 20})
+	btn.SetOnClicked(func(cw *controller.ControlWindow) {
+		dlg := walk.FileDialog{
+			Title: mi18n.T(
+				"ファイル選択ダイアログタイトル",
+				map[string]any{"Title": "Vmd"}),
+			Filter:         "Vmd files (*.vmd)|*.vmd",
+			FilterIndex:    1,
+			InitialDirPath: filepath.Dir(s.currentSet().OriginalMotionPath),
+		}
+		if ok, err := dlg.ShowOpenMultiple(nil); err != nil {
+			walk.MsgBox(nil, mi18n.T("ファイル選択ダイアログ選択エラー"), err.Error(), walk.MsgBoxIconError)
+		} else if ok {
+			// 選択したモーションは、既に選択済みのモーションの後ろに追加する（追加した順に重ねる）
+			//   - 1回で複数選択した場合、ダイアログが返す順は選び方で変わるため、その中はファイル名順とする
+			//   - 選択済みのモーションは追加しない
+			selectedPaths := slices.Clone(dlg.FilePaths)
+			sort.Slice(selectedPaths, func(i, j int) bool {
+				return filepath.Base(selectedPaths[i]) < filepath.Base(selectedPaths[j])
+			})
+			mergeMotionPaths := slices.Clone(s.currentSet().MergeMotionPaths)
+			for _, path := range selectedPaths {
+				if !slices.Contains(mergeMotionPaths, path) {
+					mergeMotionPaths = append(mergeMotionPaths, path)
+				}
+			}
+			s.currentSet().MergeMotionPaths = mergeMotionPaths
+			s.reloadMergeMotions(cw)
+		}
+	})
+	return btn
+}
+
+func (s *WidgetStore) createMergeMotionClearButton() *widget.MPushButton {
+	btn := widget.NewMPushButton()
+	btn.SetLabel(mi18n.T("重ねるモーション解除"))
+	btn.SetTooltip(mi18n.T("重ねるモーション解除説明"))
+	btn.SetMaxSize(declarative.Size{Width: 100, Height: 20})
+	btn.SetOnClicked(func(cw *controller.ControlWindow) {
+		s.currentSet().MergeMotionPaths = make([]string, 0)
+		s.reloadMergeMotions(cw)
+	})
+	return btn
+}
+
+// reloadMergeMotions 重ねるモーションの変更を反映して、元モーションを読み込み直す
+func (s *WidgetStore) reloadMergeMotions(cw *controller.ControlWindow) {
+	s.restoreMergeMotions()
+
+	if s.currentSet().OriginalMotionPath == "" {
+		return
+	}
+
+	if err := s.loadMotion(cw, s.currentSet().OriginalMotionPath); err != nil {
+		if ok := merr.ShowErrorDialog(cw.AppConfig(), err); ok {
+			s.setWidgetEnabled(true)
+		}
+	}
+}
+
+// restoreMergeMotions 現在のセットの重ねるモーションをウィジェットに反映
+func (s *WidgetStore) restoreMergeMotions() {
+	mergeMotionPaths := s.currentSet().MergeMotionPaths
+	s.MergeMotionLabel.SetText(mi18n.T("重ねるモーション件数", map[string]any{"Count": len(mergeMotionPaths)}))
+	s.MergeMotionLabel.SetToolTipText(strings.Join(mergeMotionPaths, "\n"))
+	s.MergeMotionComboBox.SetCurrentIndex(s.currentSet().MotionMergeType)
+}
+
+// createBoneAliasWidgets ユーザー定義のボーン別名ファイルの設定ウィジェット群
+func (s *WidgetStore) createBoneAliasWidgets() []declarative.Widget {
+	return []declarative.Widget{
+		declarative.TextLabel{
+			Text:        mi18n.T("ボーン別名ファイル"),
+			ToolTipText: mi18n.T("ボーン別名ファイル説明"),
+			OnMouseDown: func(x, y int, button walk.MouseButton) {
+				mlog.ILT(mi18n.T("ボーン別名ファイル"), mi18n.T("ボーン別名ファイル説明"))
+			},
+		},
+		declarative.TextLabel{
+			AssignTo: &s.BoneAliasLabel,
+			Text:     mi18n.T("ボーン別名ファイル件数", map[string]any{"Count": 0}),
+		},
+		declarative.HSpacer{},
+		s.BoneAliasButton.Widgets(),
+		s.BoneAliasClearButton.Widgets(),
+	}
+}
+
+func (s *WidgetStore) createBoneAliasButton() *widget.MPushButton {
+	btn := widget.NewMPushButton()
+	btn.SetLabel(mi18n.T("ボーン別名ファイル選択"))
+	btn.SetTooltip(mi18n.T("ボーン別名ファイル選択説明"))
+	btn.SetMaxSize(declarative.Size{Width: 100, Height: 20})
+	btn.SetOnClicked(func(cw *controller.ControlWindow) {
+		dlg := walk.FileDialog{
+			Title: mi18n.T(
+				"ファイル選択ダイアログタイトル",
+				map[string]any{"Title": "Json"}),
+			Filter:         "Json files (*.json)|*.json",
+			FilterIndex:    1,
+			InitialDirPath: filepath.Dir(s.currentSet().OriginalMotionPath),
+		}
+		if ok, err := dlg.ShowOpenMultiple(nil); err != nil {
+			walk.MsgBox(nil, mi18n.T("ファイル選択ダイアログ選択エラー"), err.Error(), walk.MsgBoxIconError)
+		} else if ok {
+			s.currentSet().BoneAliasPaths = dlg.FilePaths
+			s.reloadBoneAliases(cw)
+		}
+	})
+	return btn
+}
+
+func (s *WidgetStore) createBoneAliasClearButton() *widget.MPushButton {
+	btn := widget.NewMPushButton()
+	btn.SetLabel(mi18n.T("ボーン別名ファイル解除"))
+	btn.SetTooltip(mi18n.T("ボーン別名ファイル解除説明"))
+	btn.SetMaxSize(declarative.Size{Width: 100, Height: 20})
+	btn.SetOnClicked(func(cw *controller.ControlWindow) {
+		s.currentSet().BoneAliasPaths = make([]string, 0)
+		s.reloadBoneAliases(cw)
+	})
+	return btn
+}
+
+// reloadBoneAliases ボーン別名ファイルの変更を反映して、元モーションを読み込み直す
+//   - 適用済みの別名はキーフレームを改名しているため、読み込み直して別名を適用し直す
+func (s *WidgetStore) reloadBoneAliases(cw *controller.ControlWindow) {
+	s.restoreBoneAliases()
+
+	if s.currentSet().OriginalMotionPath == "" {
+		return
+	}
+
+	if err := s.loadMotion(cw, s.currentSet().OriginalMotionPath); err != nil {
+		if ok := merr.ShowErrorDialog(cw.AppConfig(), err); ok {
+			s.setWidgetEnabled(true)
+		}
+	}
+}
+
+// restoreBoneAliases 現在のセットのボーン別名ファイルをウィジェットに反映
+func (s *WidgetStore) restoreBoneAliases() {
+	boneAliasPaths := s.currentSet().BoneAliasPaths
+	s.BoneAliasLabel.SetText(mi18n.T("ボーン別名ファイル件数", map[string]any{"Count": len(boneAliasPaths)}))
+	s.BoneAliasLabel.SetToolTipText(strings.Join(boneAliasPaths, "\n"))
+}
+
 func (s *WidgetStore) createOriginalModelFilePicker() *widget.FilePicker {
 	return widget.NewPmxLoadFilePicker(
 		"pmx",
@@ -298,8 +497,7 @@ func (s *WidgetStore) createSaveModelButton() *widget.MPushButton {
 
 		for _, physicsSet := range s.BakeSets {
 			if physicsSet.OutputModelPath != "" && physicsSet.OriginalModel != nil {
-				rep := repository.NewPmxRepository(true)
-				if err := rep.Save(physicsSet.OutputModelPath, physicsSet.OriginalModel, false); err != nil {
+				if err := s.saveUsecase.SaveOutputModel(physicsSet, physicsSet.OutputModelPath); err != nil {
 					mlog.ET(mi18n.T("モデル保存失敗"), err, "")
 					if ok := merr.ShowErrorDialog(cw.AppConfig(), err); ok {
 						s.setWidgetEnabled(true)
@@ -442,12 +640,16 @@ func (s *WidgetStore) saveMotions() error {
 		s.Window().ProgressBar().SetValue(int(completedProcessCount))
 	})
 
-	motions, err := s.outputUsecase.ProcessOutputMotions(
+	motions, report, err := s.outputUsecase.ProcessOutputMotions(
 		bakeSet.OriginalModel,
 		bakeSet.OriginalMotion,
 		bakeSet.OutputMotion,
 		bakeSet.OutputMotionPath,
 		bakeSet.OutputRecords,
+		bakeSet.OutputSplit,
+		bakeSet.BoneNameMap,
+		bakeSet.BoneAliasMap,
+		bakeSet.OutputBoneNameType,
 		outputBoneFlags,
 		isContainsReduce,
 		incrementCompletedCount,
@@ -468,6 +670,11 @@ func (s *WidgetStore) saveMotions() error {
 		}
 	}
 
+	if err := s.saveUsecase.SaveBakeReport(report, bakeSet.OutputMotionPath); err != nil {
+		mlog.ET(mi18n.T("焼き込みレポート保存失敗エラー"), err, "")
+		return err
+	}
+
 	// 処理時間の計測終了
 	elapsed := time.Since(start)
 	mlog.IL(fmt.Sprintf(mi18n.T("モーション保存完了: 処理時間 %s"), controller.FormatDuration(elapsed)))
@@ -544,6 +751,199 @@ func (s *WidgetStore) createBakedHistoryWidgets() []declarative.Widget {
 	}
 }
 
+func (s *WidgetStore) createOutputSplitWidgets() []declarative.Widget {
+	return []declarative.Widget{
+		declarative.TextLabel{
+			Text:        mi18n.T("出力分割方法"),
+			ToolTipText: mi18n.T("出力分割方法説明"),
+			OnMouseDown: func(x, y int, button walk.MouseButton) {
+				mlog.ILT(mi18n.T("出力分割方法"), mi18n.T("出力分割方法説明"))
+			},
+		},
+		declarative.ComboBox{
+			AssignTo: &s.OutputSplitComboBox,
+			Model: []string{
+				mi18n.T("最大キーフレーム数で分割"),
+				mi18n.T("一定フレーム間隔で分割"),
+				mi18n.T("指定フレームで分割"),
+				mi18n.T("出力設定の区間毎に分割"),
+				mi18n.T("ボーングループ毎に分割"),
+			},
+			CurrentIndex:          entity.OutputSplitTypeMaxFrames,
+			OnCurrentIndexChanged: s.changeOutputSplit,
+		},
+		declarative.LineEdit{
+			AssignTo:      &s.OutputSplitParamEdit,
+			ToolTipText:   mi18n.T("出力分割パラメーター説明"),
+			OnTextChanged: s.changeOutputSplit,
+		},
+		declarative.HSpacer{
+			ColumnSpan: 1,
+		},
+		declarative.TextLabel{
+			Text:        mi18n.T("出力ボーン名"),
+			ToolTipText: mi18n.T("出力ボーン名説明"),
+			OnMouseDown: func(x, y int, button walk.MouseButton) {
+				mlog.ILT(mi18n.T("出力ボーン名"), mi18n.T("出力ボーン名説明"))
+			},
+		},
+		declarative.ComboBox{
+			AssignTo: &s.OutputBoneNameComboBox,
+			Model: []string{
+				mi18n.T("焼き込み用ボーン名で出力"),
+				mi18n.T("元モデルのボーン名で出力"),
+			},
+			CurrentIndex: entity.OutputBoneNameTypeBake,
+			OnCurrentIndexChanged: func() {
+				if s.currentSet() != nil && s.OutputBoneNameComboBox != nil {
+					s.currentSet().OutputBoneNameType = s.OutputBoneNameComboBox.CurrentIndex()
+				}
+			},
+		},
+		declarative.HSpacer{
+			ColumnSpan: 2,
+		},
+	}
+}
+
+// restoreOutputBoneNameType 現在のセットの出力ボーン名種別をプルダウンに反映
+func (s *WidgetStore) restoreOutputBoneNameType() {
+	outputBoneNameType := s.currentSet().OutputBoneNameType
+	s.OutputBoneNameComboBox.SetCurrentIndex(outputBoneNameType)
+	s.currentSet().OutputBoneNameType = outputBoneNameType
+}
+
+func (s *WidgetStore) createOutputPhysicsWidgets() []declarative.Widget {
+	return []declarative.Widget{
+		declarative.TextLabel{
+			Text:        mi18n.T("出力モデル物理"),
+			ToolTipText: mi18n.T("出力モデル物理説明"),
+			OnMouseDown: func(x, y int, button walk.MouseButton) {
+				mlog.ILT(mi18n.T("出力モデル物理"), mi18n.T("出力モデル物理説明"))
+			},
+		},
+		declarative.ComboBox{
+			AssignTo: &s.OutputPhysicsComboBox,
+			Model: []string{
+				mi18n.T("元モデルの物理のまま"),
+				mi18n.T("指定モデル物理設定の最大値"),
+				mi18n.T("指定フレーム時点の値"),
+			},
+			CurrentIndex:          entity.OutputPhysicsTypeNone,
+			OnCurrentIndexChanged: s.changeOutputPhysics,
+		},
+		declarative.LineEdit{
+			AssignTo:      &s.OutputPhysicsParamEdit,
+			ToolTipText:   mi18n.T("出力モデル物理パラメーター説明"),
+			OnTextChanged: s.changeOutputPhysics,
+		},
+		declarative.HSpacer{
+			ColumnSpan: 1,
+		},
+	}
+}
+
+// changeOutputPhysics 入力内容から現在のセットの物理書き込み方法を更新
+func (s *WidgetStore) changeOutputPhysics() {
+	if s.currentSet() == nil || s.OutputPhysicsComboBox == nil || s.OutputPhysicsParamEdit == nil {
+		return
+	}
+
+	outputPhysics := entity.NewOutputPhysics()
+	outputPhysics.Type = s.OutputPhysicsComboBox.CurrentIndex()
+
+	switch outputPhysics.Type {
+	case entity.OutputPhysicsTypeRecord:
+		// 画面上は1始まりのNoで指定する
+		if no, err := strconv.Atoi(strings.TrimSpace(s.OutputPhysicsParamEdit.Text())); err == nil {
+			outputPhysics.RecordIndex = no - 1
+		}
+	case entity.OutputPhysicsTypeFrame:
+		if frame, err := strconv.ParseFloat(strings.TrimSpace(s.OutputPhysicsParamEdit.Text()), 32); err == nil {
+			outputPhysics.Frame = float32(frame)
+		}
+	}
+
+	s.OutputPhysicsParamEdit.SetEnabled(outputPhysics.Type != entity.OutputPhysicsTypeNone)
+	s.currentSet().OutputPhysics = outputPhysics
+}
+
+// restoreOutputPhysics 現在のセットの物理書き込み方法を入力欄に反映
+func (s *WidgetStore) restoreOutputPhysics() {
+	outputPhysics := s.currentSet().OutputPhysics
+	if outputPhysics == nil {
+		outputPhysics = entity.NewOutputPhysics()
+	}
+
+	paramText := ""
+	switch outputPhysics.Type {
+	case entity.OutputPhysicsTypeRecord:
+		paramText = strconv.Itoa(outputPhysics.RecordIndex + 1)
+	case entity.OutputPhysicsTypeFrame:
+		paramText = strconv.FormatFloat(float64(outputPhysics.Frame), 'f', -1, 32)
+	}
+
+	// 入力欄の変更イベントで上書きされるため、反映後に設定し直す
+	s.OutputPhysicsComboBox.SetCurrentIndex(outputPhysics.Type)
+	s.OutputPhysicsParamEdit.SetText(paramText)
+	s.OutputPhysicsParamEdit.SetEnabled(outputPhysics.Type != entity.OutputPhysicsTypeNone)
+	s.currentSet().OutputPhysics = outputPhysics
+}
+
+// changeOutputSplit 入力内容から現在のセットの分割方法を更新
+func (s *WidgetStore) changeOutputSplit() {
+	if s.currentSet() == nil || s.OutputSplitComboBox == nil || s.OutputSplitParamEdit == nil {
+		return
+	}
+
+	outputSplit := entity.NewOutputSplit()
+	outputSplit.Type = s.OutputSplitComboBox.CurrentIndex()
+
+	switch outputSplit.Type {
+	case entity.OutputSplitTypeInterval:
+		if interval, err := strconv.ParseFloat(strings.TrimSpace(s.OutputSplitParamEdit.Text()), 64); err == nil {
+			outputSplit.Interval = interval
+		}
+	case entity.OutputSplitTypeFrames:
+		for _, text := range strings.Split(s.OutputSplitParamEdit.Text(), ",") {
+			if frame, err := strconv.ParseFloat(strings.TrimSpace(text), 32); err == nil {
+				outputSplit.Frames = append(outputSplit.Frames, float32(frame))
+			}
+		}
+	}
+
+	s.OutputSplitParamEdit.SetEnabled(
+		outputSplit.Type == entity.OutputSplitTypeInterval || outputSplit.Type == entity.OutputSplitTypeFrames)
+	s.currentSet().OutputSplit = outputSplit
+}
+
+// restoreOutputSplit 現在のセットの分割方法を入力欄に反映
+func (s *WidgetStore) restoreOutputSplit() {
+	outputSplit := s.currentSet().OutputSplit
+	if outputSplit == nil {
+		outputSplit = entity.NewOutputSplit()
+	}
+
+	paramText := ""
+	switch outputSplit.Type {
+	case entity.OutputSplitTypeInterval:
+		paramText = strconv.FormatFloat(outputSplit.Interval, 'f', -1, 64)
+	case entity.OutputSplitTypeFrames:
+		frames := make([]string, len(outputSplit.Frames))
+		for i, frame := range outputSplit.Frames {
+			frames[i] = strconv.FormatFloat(float64(frame), 'f', -1, 32)
+		}
+		paramText = strings.Join(frames, ", ")
+	}
+
+	// 入力欄の変更イベントで上書きされるため、反映後に設定し直す
+	s.OutputSplitComboBox.SetCurrentIndex(outputSplit.Type)
+	s.OutputSplitParamEdit.SetText(paramText)
+	s.OutputSplitParamEdit.SetEnabled(
+		outputSplit.Type == entity.OutputSplitTypeInterval || outputSplit.Type == entity.OutputSplitTypeFrames)
+	s.currentSet().OutputSplit = outputSplit
+}
+
 func (s *WidgetStore) createHistoryIndexChangeHandler() func() {
 	return func() {
 		// 出力モーションインデックスが変更されたときの処理
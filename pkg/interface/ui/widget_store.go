@@ -22,6 +22,13 @@ type WidgetStore struct {
 	LoadSetButton          *widget.MPushButton     // 設定読込ボタン
 	OriginalModelPicker    *widget.FilePicker      // 物理焼き込み先モデル
 	OriginalMotionPicker   *widget.FilePicker      // 物理焼き込み対象モーション
+	MergeMotionLabel       *walk.TextLabel         // 重ねるモーション件数
+	MergeMotionComboBox    *walk.ComboBox          // モーションの重ね方プルダウン
+	MergeMotionButton      *widget.MPushButton     // 重ねるモーション選択ボタン
+	MergeMotionClearButton *widget.MPushButton     // 重ねるモーション解除ボタン
+	BoneAliasLabel         *walk.TextLabel         // ボーン別名ファイル件数
+	BoneAliasButton        *widget.MPushButton     // ボーン別名ファイル選択ボタン
+	BoneAliasClearButton   *widget.MPushButton     // ボーン別名ファイル解除ボタン
 	OutputMotionPicker     *widget.FilePicker      // 出力モーション
 	OutputModelPicker      *widget.FilePicker      // 出力モデル
 	BakedHistoryIndexEdit  *walk.NumberEdit        // 出力モーションインデックスプルダウン
@@ -33,20 +40,28 @@ type WidgetStore struct {
 	AddPhysicsButton       *widget.MPushButton     // 物理設定追加ボタン
 	PhysicsTableView       *walk.TableView         // ワールド物理設定テーブル
 	AddWindButton          *widget.MPushButton     // 風設定追加ボタン
+	WindScopeCheckBox      *walk.CheckBox          // セット個別風設定チェックボックス
 	WindTableView          *walk.TableView         // 風設定テーブル
 	AddRigidBodyButton     *widget.MPushButton     // モデル物理物理追加ボタン
 	RigidBodyTableWidget   *walk.CustomWidget      // モデル物理物理テーブル
 	RigidBodyTreeModel     *RigidBodyTreeModel     // モデル物理ツリーモデル
 	AddOutputButton        *widget.MPushButton     // 出力設定追加ボタン
 	OutputTableView        *walk.TableView         // 出力定義テーブル
+	OutputSplitComboBox    *walk.ComboBox          // 出力モーション分割方法プルダウン
+	OutputSplitParamEdit   *walk.LineEdit          // 出力モーション分割パラメーター入力
+	OutputBoneNameComboBox *walk.ComboBox          // 出力モーションのボーン名プルダウン
+	CleanModelCheckBox     *walk.CheckBox          // 未加工モデル保存チェックボックス
+	OutputPhysicsComboBox  *walk.ComboBox          // 出力モデルへの物理書き込み方法プルダウン
+	OutputPhysicsParamEdit *walk.LineEdit          // 出力モデルへの物理書き込みパラメーター入力
 	BakeSets               []*entity.BakeSet       `json:"bake_sets"`       // ボーン焼き込みセット
 	PhysicsRecords         []*entity.PhysicsRecord `json:"physics_records"` // 物理設定レコード
 	WindRecords            []*entity.WindRecord    `json:"wind_records"`    // 風設定レコード
 
-	loadUsecase    *usecase.LoadUsecase
-	saveUsecase    *usecase.SaveUsecase
-	physicsUsecase *usecase.PhysicsUsecase
-	outputUsecase  *usecase.OutputUsecase
+	loadUsecase       *usecase.LoadUsecase
+	saveUsecase       *usecase.SaveUsecase
+	physicsUsecase    *usecase.PhysicsUsecase
+	outputUsecase     *usecase.OutputUsecase
+	validationUsecase *usecase.ValidationUsecase
 
 	IsTerminate atomic.Bool // モーション処理強制終了フラグ
 }
@@ -55,13 +70,14 @@ func NewWidgetStore(mWidgets *controller.MWidgets) *WidgetStore {
 	fileRepo := pRepository.NewFileRepository()
 
 	return &WidgetStore{
-		mWidgets:       mWidgets,
-		BakeSets:       make([]*entity.BakeSet, 0),
-		CurrentIndex:   -1,
-		loadUsecase:    usecase.NewLoadUsecase(fileRepo),
-		saveUsecase:    usecase.NewSaveUsecase(fileRepo),
-		physicsUsecase: usecase.NewPhysicsUsecase(),
-		outputUsecase:  usecase.NewOutputUsecase(),
+		mWidgets:          mWidgets,
+		BakeSets:          make([]*entity.BakeSet, 0),
+		CurrentIndex:      -1,
+		loadUsecase:       usecase.NewLoadUsecase(fileRepo, pRepository.NewParseCache(), pRepository.NewBoneAliasRepository()),
+		saveUsecase:       usecase.NewSaveUsecase(fileRepo, pRepository.NewReportRepository()),
+		physicsUsecase:    usecase.NewPhysicsUsecase(),
+		outputUsecase:     usecase.NewOutputUsecase(),
+		validationUsecase: usecase.NewValidationUsecase(),
 	}
 }
 
@@ -119,9 +135,23 @@ func (s *WidgetStore) changeCurrentAction(index int) {
 	// 物理焼き込み設定の情報を表示
 	s.OriginalModelPicker.ChangePath(s.currentSet().OriginalModelPath)
 	s.OriginalMotionPicker.ChangePath(s.currentSet().OriginalMotionPath)
+	s.restoreMergeMotions()
+	s.restoreBoneAliases()
 	s.OutputModelPicker.ChangePath(s.currentSet().OutputModelPath)
 	s.OutputMotionPicker.ChangePath(s.currentSet().OutputMotionPath)
 
+	// 風設定の適用範囲
+	s.WindScopeCheckBox.SetChecked(s.currentSet().WindScope == entity.WindScopeSet)
+	s.WindTableView.SetModel(newWindTableModelWithRecords(s.currentWindRecords()))
+
+	// 出力モーションの分割方法
+	s.restoreOutputSplit()
+	s.restoreOutputBoneNameType()
+
+	// 出力モデルの保存方法
+	s.CleanModelCheckBox.SetChecked(s.currentSet().OutputCleanModel)
+	s.restoreOutputPhysics()
+
 	// TODO 他のも復元
 }
 
@@ -163,6 +193,10 @@ func (s *WidgetStore) widgetList() []controller.IMWidget {
 		s.SaveSetButton,
 		s.OriginalModelPicker,
 		s.OriginalMotionPicker,
+		s.MergeMotionButton,
+		s.MergeMotionClearButton,
+		s.BoneAliasButton,
+		s.BoneAliasClearButton,
 		s.OutputModelPicker,
 		s.OutputMotionPicker,
 		s.Player,
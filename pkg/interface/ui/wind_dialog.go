@@ -437,29 +437,23 @@ func (p *WindTableViewDialog) createButtonWidgets(
 func (p *WindTableViewDialog) handleDialogOK(record *entity.WindRecord, recordIndex int) {
 	p.store.setWidgetEnabled(false)
 
+	records := p.store.currentWindRecords()
 	if recordIndex == -1 {
-		p.store.WindRecords =
-			append(p.store.WindRecords, record)
-		p.store.WindTableView.SetCurrentIndex(len(p.store.WindRecords) - 1)
+		records = append(records, record)
+		p.store.setCurrentWindRecords(records)
+		p.store.WindTableView.SetCurrentIndex(len(records) - 1)
 	} else {
-		p.store.WindRecords[recordIndex] = record
+		records[recordIndex] = record
 		p.store.WindTableView.SetCurrentIndex(recordIndex)
 	}
 
-	windMotion := vmd.NewVmdMotion("")
-
-	p.store.physicsUsecase.ApplyWindMotion(
-		windMotion,
-		p.store.WindRecords,
-	)
-
-	p.store.mWidgets.Window().StoreWindMotion(0, windMotion)
+	p.store.applyWindMotions()
 	p.store.mWidgets.Window().TriggerPhysicsReset()
 
 	p.store.setWidgetEnabled(true)
 
 	// 更新
-	p.store.WindTableView.SetModel(newWindTableModelWithRecords(p.store.WindRecords))
+	p.store.WindTableView.SetModel(newWindTableModelWithRecords(p.store.currentWindRecords()))
 }
 
 func (p *WindTableViewDialog) onChangeValue() {
@@ -483,14 +477,18 @@ func (p *WindTableViewDialog) onChangeValue() {
 	record.WindConfig.DragCoeff = float32(p.dragCoeffEdit.Value())
 	record.WindConfig.LiftCoeff = float32(p.liftCoeffEdit.Value())
 
-	windMotion := vmd.NewVmdMotion("")
-
-	p.store.physicsUsecase.ApplyWindMotion(
-		windMotion,
-		[]*entity.WindRecord{record},
-	)
+	if p.store.currentSet().WindScope == entity.WindScopeSet {
+		// セット個別の風は、剛体の設定と合わせてモデル物理モーションに適用（全体共通の風はそのまま）
+		p.store.applyPhysicsModelMotionWithWind(p.store.CurrentIndex, []*entity.WindRecord{record})
+	} else {
+		windMotion := vmd.NewVmdMotion("")
+		p.store.physicsUsecase.ApplyWindMotion(
+			windMotion,
+			[]*entity.WindRecord{record},
+		)
+		p.store.mWidgets.Window().StoreWindMotion(0, windMotion)
+	}
 
-	p.store.mWidgets.Window().StoreWindMotion(0, windMotion)
 	p.store.mWidgets.Window().TriggerPhysicsReset()
 
 	p.store.setWidgetEnabled(true)
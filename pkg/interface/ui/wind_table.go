@@ -42,7 +42,7 @@ func createWindTableViewDialog(store *WidgetStore, isAdd bool) func() {
 				record = entity.NewWindRecord(store.minFrame(), store.maxFrame())
 			}
 		case false:
-			record = store.WindRecords[store.WindTableView.CurrentIndex()]
+			record = store.currentWindRecords()[store.WindTableView.CurrentIndex()]
 			recordIndex = store.WindTableView.CurrentIndex()
 		}
 		dialog := newWindTableViewDialog(store)
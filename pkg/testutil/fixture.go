@@ -0,0 +1,98 @@
+// テスト用に、コード上で組み立てる最小構成のモデル・モーション
+package testutil
+
+import (
+	"github.com/miu200521358/mlib_go/pkg/domain/mmath"
+	"github.com/miu200521358/mlib_go/pkg/domain/pmx"
+	"github.com/miu200521358/mlib_go/pkg/domain/vmd"
+)
+
+const (
+	BoneCenter    = "センター" // ルート（表示枠あり）
+	BoneUpperBody = "上半身"  // センターの子（表示枠あり）
+	BoneHair1     = "髪1"   // 上半身の子・物理剛体あり（表示枠あり）
+	BoneHair2     = "髪2"   // 髪1の子・物理剛体あり（表示枠なし）
+
+	RigidBodyHair1 = "髪1"    // 髪1ボーンの物理剛体
+	RigidBodyHair2 = "髪2"    // 髪2ボーンの物理剛体
+	JointHair      = "髪1-髪2" // 髪1と髪2を繋ぐジョイント
+)
+
+// NewModel センター → 上半身 → 髪1 → 髪2 の4ボーンと、髪の剛体2つ・ジョイント1つを持つモデル
+// 髪2は表示枠に登録しない
+func NewModel() *pmx.PmxModel {
+	model := pmx.NewPmxModel("fixture.pmx")
+	model.SetName("fixture")
+
+	appendBone(model, BoneCenter, -1, &mmath.MVec3{X: 0, Y: 8, Z: 0})
+	appendBone(model, BoneUpperBody, 0, &mmath.MVec3{X: 0, Y: 12, Z: 0})
+	appendBone(model, BoneHair1, 1, &mmath.MVec3{X: 0, Y: 18, Z: 1})
+	appendBone(model, BoneHair2, 2, &mmath.MVec3{X: 0, Y: 15, Z: 1.5})
+
+	appendRigidBody(model, RigidBodyHair1, 2)
+	appendRigidBody(model, RigidBodyHair2, 3)
+
+	joint := pmx.NewJoint()
+	joint.SetName(JointHair)
+	joint.RigidBodyIndexA = 0
+	joint.RigidBodyIndexB = 1
+	model.Joints.Append(joint)
+
+	slot := pmx.NewDisplaySlot()
+	slot.SetName("fixture")
+	for _, boneIndex := range []int{0, 1, 2} {
+		slot.References = append(slot.References, pmx.NewDisplaySlotReferenceByValues(pmx.DISPLAY_TYPE_BONE, boneIndex))
+	}
+	model.DisplaySlots.Append(slot)
+
+	model.Bones.Setup()
+	model.RigidBodies.Setup(model.Bones)
+
+	// 表示枠の登録有無
+	model.Bones.ForEach(func(boneIndex int, bone *pmx.Bone) bool {
+		if boneIndex <= 2 {
+			bone.DisplaySlotIndex = 0
+		} else {
+			bone.DisplaySlotIndex = -1
+		}
+		return true
+	})
+
+	return model
+}
+
+// NewMotion センターに0F・10F、上半身に5Fのキーフレームを持つモーション
+func NewMotion() *vmd.VmdMotion {
+	motion := vmd.NewVmdMotion("fixture.vmd")
+
+	for _, f := range []float32{0, 10} {
+		motion.AppendBoneFrame(BoneCenter, vmd.NewBoneFrame(f))
+	}
+	motion.AppendBoneFrame(BoneUpperBody, vmd.NewBoneFrame(5))
+
+	return motion
+}
+
+func appendBone(model *pmx.PmxModel, name string, parentIndex int, position *mmath.MVec3) {
+	bone := pmx.NewBone()
+	bone.SetName(name)
+	bone.ParentIndex = parentIndex
+	bone.Position = position
+	bone.BoneFlag = pmx.BONE_FLAG_IS_VISIBLE | pmx.BONE_FLAG_CAN_MANIPULATE | pmx.BONE_FLAG_CAN_ROTATE
+	model.Bones.Append(bone)
+}
+
+func appendRigidBody(model *pmx.PmxModel, name string, boneIndex int) {
+	bone, _ := model.Bones.Get(boneIndex)
+
+	rigidBody := pmx.NewRigidBody()
+	rigidBody.SetName(name)
+	rigidBody.BoneIndex = boneIndex
+	rigidBody.Bone = bone
+	rigidBody.ShapeType = pmx.SHAPE_CAPSULE
+	rigidBody.PhysicsType = pmx.PHYSICS_TYPE_DYNAMIC
+	rigidBody.Position = bone.Position.Copy()
+	rigidBody.Size = &mmath.MVec3{X: 0.5, Y: 2, Z: 0}
+	rigidBody.RigidBodyParam.Mass = 1
+	model.RigidBodies.Append(rigidBody)
+}